@@ -0,0 +1,370 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+)
+
+// Options selects what to generate.
+type Options struct {
+	Package string   // Go package name of the generated file
+	Catalog string   // Catalog to generate (required)
+	Schemas []string // Schemas to include (all if empty)
+	Tables  []string // Tables to include (all if empty)
+}
+
+// goType is the Go type generated for a column.
+type goType struct {
+	name     string
+	imported string // import path needed by the type, if any
+	nilable  bool   // already nil-able (no pointer needed for NULL)
+}
+
+// typePattern extracts the base type name, e.g. "decimal" from "decimal(10,2)".
+var typePattern = regexp.MustCompile(`^\s*([a-zA-Z ]+?)\s*(\(|$)`)
+
+// mapType maps a catalog data type (Trino/SQL naming) to a Go type.
+// Temporal types are kept as strings because their JSON formatting depends
+// on the data dock's engine.
+func mapType(dataType string) goType {
+	base := strings.ToLower(dataType)
+	if match := typePattern.FindStringSubmatch(base); match != nil {
+		base = match[1]
+	}
+
+	switch base {
+	case "bigint", "int8", "long":
+		return goType{name: "int64"}
+	case "integer", "int", "int4":
+		return goType{name: "int32"}
+	case "smallint", "int2":
+		return goType{name: "int16"}
+	case "tinyint":
+		return goType{name: "int8"}
+	case "real", "float4":
+		return goType{name: "float32"}
+	case "double", "double precision", "float", "float8":
+		return goType{name: "float64"}
+	case "decimal", "numeric":
+		return goType{name: "json.Number", imported: "encoding/json"}
+	case "boolean", "bool":
+		return goType{name: "bool"}
+	case "varchar", "char", "character", "character varying", "text", "string", "uuid",
+		"date", "time", "timestamp", "timestamp with time zone", "time with time zone",
+		"varbinary", "ipaddress", "interval":
+		return goType{name: "string"}
+	case "json", "jsonb":
+		return goType{name: "json.RawMessage", imported: "encoding/json", nilable: true}
+	case "array":
+		return goType{name: "[]any", nilable: true}
+	case "map", "row":
+		return goType{name: "map[string]any", nilable: true}
+	}
+	return goType{name: "any", nilable: true}
+}
+
+// initialisms are rendered in upper case, following Go naming conventions.
+var initialisms = map[string]bool{
+	"API": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "URL": true, "URI": true, "UUID": true,
+}
+
+// exportedName converts a SQL identifier (snake_case, kebab-case, spaces)
+// into an exported Go identifier.
+func exportedName(identifier string) string {
+	words := strings.FieldsFunc(identifier, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		if word == strings.ToUpper(word) {
+			word = strings.ToLower(word)
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	name := b.String()
+	if name == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// uniqueNamer hands out identifiers, suffixing duplicates with a number.
+type uniqueNamer map[string]bool
+
+func (n uniqueNamer) name(base string) string {
+	return n.group(base, func(name string) []string { return []string{name} })
+}
+
+// group returns the first of base, base2, base3... for which none of the
+// identifiers derived from it are taken, and reserves all of them.
+func (n uniqueNamer) group(base string, derived func(name string) []string) string {
+	for i := 1; ; i++ {
+		name := base
+		if i > 1 {
+			name += strconv.Itoa(i)
+		}
+		identifiers := derived(name)
+		if !slices.ContainsFunc(identifiers, func(identifier string) bool { return n[identifier] }) {
+			for _, identifier := range identifiers {
+				n[identifier] = true
+			}
+			return name
+		}
+	}
+}
+
+// tableIdentifiers returns the package-level identifiers writeTable
+// declares for a table named name.
+func tableIdentifiers(name string, columns []generatedColumn) []string {
+	identifiers := []string{name, "Get" + name, "Insert" + name, name + "Table", name + "Columns"}
+	for _, column := range columns {
+		identifiers = append(identifiers, name+"Col"+column.field)
+	}
+	return identifiers
+}
+
+type generatedTable struct {
+	schema  string
+	table   builders.Table
+	goName  string
+	columns []generatedColumn
+}
+
+type generatedColumn struct {
+	column builders.Column
+	field  string
+	typ    goType
+}
+
+// Generate renders a gofmt-ed Go file with, for each selected table, a row
+// struct, column name constants and typed query helpers.
+func Generate(catalogs []builders.Catalog, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "models"
+	}
+	catalog, ok := builders.FindCatalog(catalogs, opts.Catalog)
+	if !ok {
+		return nil, fmt.Errorf("catalog %q not found", opts.Catalog)
+	}
+
+	tables := selectTables(catalog, opts)
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables selected in catalog %q", opts.Catalog)
+	}
+
+	imports := map[string]bool{
+		"context": true,
+		"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent": true,
+		"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils":           true,
+	}
+	for _, table := range tables {
+		for _, column := range table.columns {
+			if column.typ.imported != "" {
+				imports[column.typ.imported] = true
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by bifrost-gen from catalog %q. DO NOT EDIT.\n\n", opts.Catalog)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+	b.WriteString("import (\n")
+	for _, path := range sortedKeys(imports) {
+		if strings.Contains(path, ".") {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString("\n")
+	for _, path := range sortedKeys(imports) {
+		if strings.Contains(path, ".") {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	b.WriteString(")\n")
+
+	for _, table := range tables {
+		writeTable(&b, opts.Catalog, table)
+	}
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return source, nil
+}
+
+// selectTables filters and names the tables to generate, in schema then
+// table order. Table names shared by several schemas are prefixed with the
+// schema name.
+func selectTables(catalog *builders.Catalog, opts Options) []generatedTable {
+	schemaFilter := toSet(opts.Schemas)
+	tableFilter := toSet(opts.Tables)
+
+	var tables []generatedTable
+	tableCount := map[string]int{}
+	for _, schema := range catalog.Schemas {
+		if len(schemaFilter) > 0 && !schemaFilter[schema.Name] {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if len(tableFilter) > 0 && !tableFilter[table.Name] {
+				continue
+			}
+			tables = append(tables, generatedTable{schema: schema.Name, table: table})
+			tableCount[table.Name]++
+		}
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool {
+		if tables[i].schema != tables[j].schema {
+			return tables[i].schema < tables[j].schema
+		}
+		return tables[i].table.Name < tables[j].table.Name
+	})
+
+	// Every table declares several identifiers (see tableIdentifiers), which
+	// must not collide with those of other tables, e.g. GetOrders for the
+	// orders table and the row struct of get_orders.
+	names := uniqueNamer{}
+	for i := range tables {
+		fields := uniqueNamer{}
+		for _, column := range tables[i].table.Columns {
+			tables[i].columns = append(tables[i].columns, generatedColumn{
+				column: column,
+				field:  fields.name(exportedName(column.Name)),
+				typ:    mapType(column.DataType),
+			})
+		}
+
+		base := exportedName(tables[i].table.Name)
+		if tableCount[tables[i].table.Name] > 1 {
+			base = exportedName(tables[i].schema) + base
+		}
+		tables[i].goName = names.group(base, func(name string) []string {
+			return tableIdentifiers(name, tables[i].columns)
+		})
+	}
+	return tables
+}
+
+// writeTable declares the identifiers returned by tableIdentifiers.
+func writeTable(b *bytes.Buffer, catalogName string, table generatedTable) {
+	name := table.goName
+	path := fmt.Sprintf("%s.%s.%s", catalogName, table.schema, table.table.Name)
+
+	// Row struct
+	fmt.Fprintf(b, "\n// %s is a row of %s.\n", name, path)
+	if table.table.Comment != "" {
+		writeComment(b, "", table.table.Comment)
+	}
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, column := range table.columns {
+		if column.column.Comment != "" {
+			writeComment(b, "\t", column.column.Comment)
+		}
+		typeName, tag := column.typ.name, column.column.Name
+		if column.column.Nullable {
+			if !column.typ.nilable {
+				typeName = "*" + typeName
+			}
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q` // %s\n", column.field, typeName, tag, column.column.DataType)
+	}
+	b.WriteString("}\n")
+
+	// Column constants
+	fmt.Fprintf(b, "\n// Column names of %s, for Select, Where and OrderBy.\n", path)
+	b.WriteString("const (\n")
+	for _, column := range table.columns {
+		fmt.Fprintf(b, "\t%sCol%s = %q\n", name, column.field, column.column.Name)
+	}
+	b.WriteString(")\n")
+
+	// Query helpers
+	fmt.Fprintf(b, `
+// %[1]sColumns lists the columns of %[2]s in table order.
+var %[1]sColumns = []string{%[3]s}
+
+// %[1]sTable points base (e.g. client.DataDock(id)) at %[2]s.
+func %[1]sTable(base *fluent.QueryBuilder) *fluent.QueryBuilder {
+	return base.Catalog(%[4]q).Schema(%[5]q).Table(%[6]q)
+}
+
+// Get%[1]s runs query (built from %[1]sTable) and decodes the rows.
+func Get%[1]s(ctx context.Context, query *fluent.QueryBuilder) ([]%[1]s, error) {
+	resp, err := query.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows []%[1]s
+	if resp.Data == nil {
+		return rows, nil
+	}
+	if err := utils.UnmarshalData(resp.Data, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Insert%[1]s inserts rows into %[2]s.
+func Insert%[1]s(ctx context.Context, base *fluent.QueryBuilder, rows ...%[1]s) (*utils.Response, error) {
+	return %[1]sTable(base).Post(ctx, rows)
+}
+`, name, path, columnConstants(table), catalogName, table.schema, table.table.Name)
+}
+
+func writeComment(b *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func columnConstants(table generatedTable) string {
+	names := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		names[i] = fmt.Sprintf("%sCol%s", table.goName, column.field)
+	}
+	return strings.Join(names, ", ")
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
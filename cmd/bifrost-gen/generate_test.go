@@ -0,0 +1,231 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+)
+
+var testCatalogs = []builders.Catalog{{
+	Name: "sales",
+	Schemas: []builders.Schema{
+		{Name: "public", Tables: []builders.Table{
+			{Name: "order_items", Comment: "One line per ordered product.", Columns: []builders.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "order_id", DataType: "bigint"},
+				{Name: "unit_price", DataType: "decimal(10,2)", Nullable: true},
+				{Name: "tags", DataType: "array(varchar)", Nullable: true},
+				{Name: "shipped", DataType: "boolean", Nullable: true, Comment: "Set once shipped."},
+			}},
+			{Name: "events", Columns: []builders.Column{{Name: "id", DataType: "integer"}}},
+		}},
+		{Name: "archive", Tables: []builders.Table{
+			{Name: "events", Columns: []builders.Column{{Name: "id", DataType: "integer"}}},
+		}},
+	},
+}}
+
+// typeCheck fails the test if the generated source does not compile. The
+// imports are read from the export data of the build cache.
+func typeCheck(t *testing.T, source []byte) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "models.go", source, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, source)
+	}
+
+	args := []string{"list", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}"}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		args = append(args, path)
+	}
+	out, err := exec.Command("go", args...).Output()
+	if err != nil {
+		t.Fatalf("Listing the imports: %v", err)
+	}
+	exports := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		path, export, _ := strings.Cut(line, "=")
+		exports[path] = export
+	}
+	lookup := func(path string) (io.ReadCloser, error) {
+		return os.Open(exports[path])
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(fset, "gc", lookup)}
+	if _, err := config.Check("models", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("Generated code does not compile: %v\n%s", err, source)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := Generate(testCatalogs, Options{Package: "models", Catalog: "sales"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	typeCheck(t, source)
+
+	// Compare with whitespace collapsed so gofmt alignment doesn't matter
+	code := strings.Join(strings.Fields(string(source)), " ")
+	for _, want := range []string{
+		"// Code generated by bifrost-gen",
+		"package models",
+		`"encoding/json"`,
+		"// One line per ordered product.",
+		"type OrderItems struct {",
+		"ID int64 `json:\"id\"`",
+		"OrderID int64 `json:\"order_id\"`",
+		"UnitPrice *json.Number `json:\"unit_price,omitempty\"`",
+		"Tags []any `json:\"tags,omitempty\"`",
+		"// Set once shipped.",
+		"Shipped *bool `json:\"shipped,omitempty\"`",
+		`OrderItemsColOrderID = "order_id"`,
+		"var OrderItemsColumns = []string{OrderItemsColID, OrderItemsColOrderID,",
+		`return base.Catalog("sales").Schema("public").Table("order_items")`,
+		"func GetOrderItems(ctx context.Context, query *fluent.QueryBuilder) ([]OrderItems, error) {",
+		"func InsertOrderItems(ctx context.Context, base *fluent.QueryBuilder, rows ...OrderItems) (*utils.Response, error) {",
+		// Same table name in two schemas
+		"type ArchiveEvents struct {",
+		"type PublicEvents struct {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Generated code is missing %q\n%s", want, source)
+		}
+	}
+}
+
+func TestGenerate_CollidingIdentifiers(t *testing.T) {
+	id := []builders.Column{{Name: "id", DataType: "bigint"}}
+	catalogs := []builders.Catalog{{Name: "sales", Schemas: []builders.Schema{{Name: "public", Tables: []builders.Table{
+		{Name: "orders", Columns: id},
+		{Name: "get_orders", Columns: id},
+		{Name: "orders_columns", Columns: id},
+		{Name: "orders_col_id", Columns: id},
+	}}}}}
+
+	source, err := Generate(catalogs, Options{Catalog: "sales"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	typeCheck(t, source)
+
+	code := string(source)
+	for _, want := range []string{
+		// Tables are named in order, get_orders first: it declares
+		// GetOrders, GetOrdersColumns and GetOrdersColID.
+		"type GetOrders struct",
+		"func GetGetOrders(",
+		"type Orders2 struct",
+		"func GetOrders2(",
+		"var Orders2Columns = []string{Orders2ColID}",
+		"type OrdersColID2 struct",
+		"type OrdersColumns2 struct",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Generated code is missing %q\n%s", want, source)
+		}
+	}
+}
+
+func TestGenerate_Filters(t *testing.T) {
+	source, err := Generate(testCatalogs, Options{Catalog: "sales", Schemas: []string{"archive"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	code := string(source)
+	if !strings.Contains(code, "package models") || !strings.Contains(code, "type Events struct") {
+		t.Errorf("Expected only archive.events without a schema prefix\n%s", code)
+	}
+	if strings.Contains(code, "OrderItems") || strings.Contains(code, `"encoding/json"`) {
+		t.Errorf("Expected other schemas and unused imports to be left out\n%s", code)
+	}
+
+	if _, err := Generate(testCatalogs, Options{Catalog: "missing"}); err == nil {
+		t.Error("Expected an error for an unknown catalog")
+	}
+	if _, err := Generate(testCatalogs, Options{Catalog: "sales", Tables: []string{"missing"}}); err == nil {
+		t.Error("Expected an error when no table is selected")
+	}
+}
+
+func TestMapType(t *testing.T) {
+	tests := map[string]string{
+		"bigint":                      "int64",
+		"INTEGER":                     "int32",
+		"smallint":                    "int16",
+		"real":                        "float32",
+		"double":                      "float64",
+		"decimal(38,0)":               "json.Number",
+		"varchar(255)":                "string",
+		"timestamp(3) with time zone": "string",
+		"json":                        "json.RawMessage",
+		"map(varchar, bigint)":        "map[string]any",
+		"row(a integer)":              "map[string]any",
+		"geometry":                    "any",
+	}
+	for dataType, want := range tests {
+		if got := mapType(dataType).name; got != want {
+			t.Errorf("mapType(%q) = %q, want %q", dataType, got, want)
+		}
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"order_id":    "OrderID",
+		"api-key":     "APIKey",
+		"Created At":  "CreatedAt",
+		"2fa_enabled": "X2faEnabled",
+		"__":          "X",
+		"customerURL": "CustomerURL",
+		"ORDER_DATE":  "OrderDate",
+	}
+	for identifier, want := range tests {
+		if got := exportedName(identifier); got != want {
+			t.Errorf("exportedName(%q) = %q, want %q", identifier, got, want)
+		}
+	}
+}
+
+func TestRun_FromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "snapshot.json")
+	output := filepath.Join(dir, "models.go")
+
+	snapshot := &builders.CatalogSnapshot{DataDockID: "dd", Catalogs: testCatalogs}
+	file, err := os.Create(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.WriteJSON(file); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	if err := run([]string{"-input", input, "-catalog", "sales", "-tables", "order_items", "-package", "gen", "-o", output}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	source, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), "package gen") || !strings.Contains(string(source), "type OrderItems struct") {
+		t.Errorf("Unexpected output\n%s", source)
+	}
+
+	if err := run([]string{"-input", input}); err == nil {
+		t.Error("Expected an error without -catalog")
+	}
+}
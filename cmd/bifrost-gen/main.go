@@ -0,0 +1,108 @@
+// Command bifrost-gen generates Go structs, column constants and typed query
+// helpers from a data dock's catalog metadata.
+//
+// The catalog is read live through the SDK (configured from the HYPERFLUID_*
+// environment variables, or a .env file) or from a saved JSON file, either a
+// CatalogSnapshot or a raw {"catalogs": [...]} response:
+//
+//	bifrost-gen -catalog sales -schema public -package models -o models/sales.go
+//	bifrost-gen -input snapshot.json -catalog sales -tables orders,customers
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "bifrost-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	_ = godotenv.Load()
+
+	flags := flag.NewFlagSet("bifrost-gen", flag.ContinueOnError)
+	input := flags.String("input", "", "read the catalog from a saved JSON file instead of the API")
+	dataDock := flags.String("datadock", os.Getenv("HYPERFLUID_DATADOCK_ID"), "data dock to read the catalog from")
+	catalog := flags.String("catalog", "", "catalog to generate (required)")
+	schemas := flags.String("schema", "", "comma-separated schemas to include (default all)")
+	tables := flags.String("tables", "", "comma-separated tables to include (default all)")
+	pkg := flags.String("package", "models", "package name of the generated file")
+	output := flags.String("o", "", "output file (default stdout)")
+	timeout := flags.Duration("timeout", time.Minute, "timeout for fetching the catalog")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *catalog == "" {
+		return fmt.Errorf("-catalog is required")
+	}
+
+	var catalogs []builders.Catalog
+	var err error
+	if *input != "" {
+		catalogs, err = readCatalogs(*input)
+	} else {
+		if *dataDock == "" {
+			return fmt.Errorf("-datadock (or HYPERFLUID_DATADOCK_ID) is required without -input")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		catalogs, err = builders.FetchCatalogs(ctx, sdk.NewClient(utils.ConfigurationFromEnv()), *dataDock)
+	}
+	if err != nil {
+		return err
+	}
+
+	source, err := Generate(catalogs, Options{
+		Package: *pkg,
+		Catalog: *catalog,
+		Schemas: splitList(*schemas),
+		Tables:  splitList(*tables),
+	})
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(*output, source, 0o644)
+}
+
+// readCatalogs reads a CatalogSnapshot or a raw catalog API response.
+func readCatalogs(path string) ([]builders.Catalog, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", utils.ErrInvalidResponse, path, err)
+	}
+	return builders.DecodeCatalogs(data)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
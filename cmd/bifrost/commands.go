@@ -0,0 +1,582 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// commands maps each command path to its handler.
+var commands = map[string]func(path string, args []string, stdout io.Writer) error{
+	"org harbors list":        orgHarborsList,
+	"org datadocks list":      orgDataDocksList,
+	"harbor datadocks list":   harborDataDocksList,
+	"harbor datadocks create": harborDataDocksCreate,
+	"datadock get":            dataDockGet,
+	"datadock wakeup":         dataDockWakeUp,
+	"datadock sleep":          dataDockSleep,
+	"datadock refresh":        dataDockRefresh,
+	"datadock catalog":        dataDockCatalog,
+	"shell":                   shellCommand,
+	"query":                   query,
+	"search":                  search,
+	"s3 ls":                   s3List,
+	"s3 get":                  s3Get,
+}
+
+// Columns printed for resources in table and CSV output.
+var (
+	harborColumns   = []string{"id", "name", "created_at"}
+	dataDockColumns = []string{"id", "name", "harbor_id", "status", "catalog_refreshed_at"}
+)
+
+func orgHarborsList(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	orgID := flags.String("org", "", "organization (default HYPERFLUID_ORG_ID)")
+	prefix := flags.String("prefix", "", "only harbors whose name starts with this prefix")
+	if _, err := parse(flags, opts, args, 0, 0); err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	org, err := orDefault(*orgID, s.config.OrgID, "-org (or HYPERFLUID_ORG_ID)")
+	if err != nil {
+		return err
+	}
+	var harbors []progressive.Harbor
+	for harbor, err := range s.client.Org(org).AllHarbors(s.ctx, progressive.ListOptions{NamePrefix: *prefix}) {
+		if err != nil {
+			return err
+		}
+		harbors = append(harbors, harbor)
+	}
+	return s.out.printValues(harborColumns, harbors)
+}
+
+func orgDataDocksList(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	orgID := flags.String("org", "", "organization (default HYPERFLUID_ORG_ID)")
+	prefix := flags.String("prefix", "", "only data docks whose name starts with this prefix")
+	status := flags.String("status", "", "only data docks in this status, e.g. sleeping")
+	if _, err := parse(flags, opts, args, 0, 0); err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	org, err := orDefault(*orgID, s.config.OrgID, "-org (or HYPERFLUID_ORG_ID)")
+	if err != nil {
+		return err
+	}
+	listOpts := progressive.ListOptions{NamePrefix: *prefix, Status: progressive.DataDockStatus(*status)}
+	var dataDocks []progressive.DataDock
+	for dataDock, err := range s.client.Org(org).AllDataDocks(s.ctx, listOpts) {
+		if err != nil {
+			return err
+		}
+		dataDocks = append(dataDocks, dataDock)
+	}
+	return s.out.printValues(dataDockColumns, dataDocks)
+}
+
+func harborDataDocksList(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	prefix := flags.String("prefix", "", "only data docks whose name starts with this prefix")
+	status := flags.String("status", "", "only data docks in this status, e.g. sleeping")
+	positional, err := parse(flags, opts, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	listOpts := progressive.ListOptions{NamePrefix: *prefix, Status: progressive.DataDockStatus(*status)}
+	var dataDocks []progressive.DataDock
+	for dataDock, err := range s.client.OrgFromConfig().Harbor(positional[0]).AllDataDocks(s.ctx, listOpts) {
+		if err != nil {
+			return err
+		}
+		dataDocks = append(dataDocks, dataDock)
+	}
+	return s.out.printValues(dataDockColumns, dataDocks)
+}
+
+func harborDataDocksCreate(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	name := flags.String("name", "", "name of the data dock (required)")
+	description := flags.String("description", "", "description of the data dock")
+	kind := flags.String("type", "", "connection type, e.g. Trino or TrinoInternal (required)")
+	config := flags.String("config", "", "connection settings as a JSON object")
+	positional, err := parse(flags, opts, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	req := progressive.CreateDataDockRequest{
+		Name:        *name,
+		Description: *description,
+		Connection:  progressive.ConnectionKind{Type: *kind},
+	}
+	if *config != "" {
+		if err := json.Unmarshal([]byte(*config), &req.Connection.Config); err != nil {
+			return fmt.Errorf("%w: -config: %w", utils.ErrInvalidRequest, err)
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	dataDock, err := s.client.OrgFromConfig().Harbor(positional[0]).CreateDataDock(s.ctx, req)
+	if err != nil {
+		return err
+	}
+	return s.out.printValues(dataDockColumns, dataDock)
+}
+
+// dataDockCommand parses the flags of a datadock command, whose optional
+// argument is the data dock (default HYPERFLUID_DATADOCK_ID).
+func dataDockCommand(path string, args []string, stdout io.Writer, define func(flags *flag.FlagSet)) (*session, *progressive.DataDockBuilder, func(), error) {
+	flags, opts := newFlags(path)
+	if define != nil {
+		define(flags)
+	}
+	positional, err := parse(flags, opts, args, 0, 1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var dataDockID string
+	if len(positional) == 1 {
+		dataDockID = positional[0]
+	}
+	dataDockID, err = orDefault(dataDockID, s.config.DataDockID, "a data dock (or HYPERFLUID_DATADOCK_ID)")
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	return s, s.client.OrgFromConfig().Harbor("").DataDock(dataDockID), cancel, nil
+}
+
+func dataDockGet(path string, args []string, stdout io.Writer) error {
+	s, dataDock, cancel, err := dataDockCommand(path, args, stdout, nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	details, err := dataDock.Get(s.ctx)
+	if err != nil {
+		return err
+	}
+	return s.out.printValues(dataDockColumns, details)
+}
+
+func dataDockWakeUp(path string, args []string, stdout io.Writer) error {
+	var wait *bool
+	s, dataDock, cancel, err := dataDockCommand(path, args, stdout, func(flags *flag.FlagSet) {
+		wait = flags.Bool("wait", false, "wait until the data dock is running (bounded by -timeout)")
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if *wait {
+		details, err := dataDock.WakeUpAndWait(s.ctx, progressive.WaitOptions{})
+		if err != nil {
+			return err
+		}
+		return s.out.printValues(dataDockColumns, details)
+	}
+	if err := checkResponse(dataDock.WakeUp(s.ctx)); err != nil {
+		return err
+	}
+	details, err := dataDock.Get(s.ctx)
+	if err != nil {
+		return err
+	}
+	return s.out.printValues(dataDockColumns, details)
+}
+
+func dataDockSleep(path string, args []string, stdout io.Writer) error {
+	var wait *bool
+	s, dataDock, cancel, err := dataDockCommand(path, args, stdout, func(flags *flag.FlagSet) {
+		wait = flags.Bool("wait", false, "wait until the data dock is sleeping (bounded by -timeout)")
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := checkResponse(dataDock.Sleep(s.ctx)); err != nil {
+		return err
+	}
+	if *wait {
+		details, err := dataDock.WaitFor(s.ctx, progressive.DataDockSleeping, progressive.WaitOptions{})
+		if err != nil {
+			return err
+		}
+		return s.out.printValues(dataDockColumns, details)
+	}
+	details, err := dataDock.Get(s.ctx)
+	if err != nil {
+		return err
+	}
+	return s.out.printValues(dataDockColumns, details)
+}
+
+func dataDockRefresh(path string, args []string, stdout io.Writer) error {
+	var wait *bool
+	s, dataDock, cancel, err := dataDockCommand(path, args, stdout, func(flags *flag.FlagSet) {
+		wait = flags.Bool("wait", false, "wait until the catalog is refreshed (bounded by -timeout)")
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	op, err := dataDock.RefreshCatalog(s.ctx)
+	if err != nil {
+		return err
+	}
+	results := op.Results()
+	if *wait {
+		results, err = op.Wait(s.ctx, progressive.WaitOptions{})
+	}
+
+	rows := make([]map[string]any, 0, len(results))
+	for _, result := range results {
+		row := map[string]any{
+			"datadock_id":  result.DataDockID,
+			"done":         result.Done,
+			"refreshed_at": nil,
+			"error":        nil,
+		}
+		if result.RefreshedAt != nil {
+			row["refreshed_at"] = result.RefreshedAt
+		}
+		if result.Err != nil {
+			row["error"] = result.Err.Error()
+		}
+		rows = append(rows, row)
+	}
+	if printErr := s.out.printValues([]string{"datadock_id", "done", "refreshed_at", "error"}, rows); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+func dataDockCatalog(path string, args []string, stdout io.Writer) error {
+	var catalogName, schemaName, tableName *string
+	s, dataDock, cancel, err := dataDockCommand(path, args, stdout, func(flags *flag.FlagSet) {
+		catalogName = flags.String("catalog", "", "only this catalog")
+		schemaName = flags.String("schema", "", "only this schema")
+		tableName = flags.String("table", "", "only this table")
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	catalogs, err := dataDock.Catalogs(s.ctx)
+	if err != nil {
+		return err
+	}
+	var rows []map[string]any
+	for _, catalog := range catalogs {
+		if *catalogName != "" && catalog.Name != *catalogName {
+			continue
+		}
+		for _, schema := range catalog.Schemas {
+			if *schemaName != "" && schema.Name != *schemaName {
+				continue
+			}
+			for _, table := range schema.Tables {
+				if *tableName != "" && table.Name != *tableName {
+					continue
+				}
+				for _, column := range table.Columns {
+					rows = append(rows, map[string]any{
+						"catalog":   catalog.Name,
+						"schema":    schema.Name,
+						"table":     table.Name,
+						"column":    column.Name,
+						"data_type": column.DataType,
+						"nullable":  column.Nullable,
+					})
+				}
+			}
+		}
+	}
+	return s.out.print([]string{"catalog", "schema", "table", "column", "data_type", "nullable"}, rows)
+}
+
+func query(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	dataDockID := flags.String("datadock", "", "data dock (default HYPERFLUID_DATADOCK_ID)")
+	var spec querySpec
+	flags.Var(&spec.selects, "select", "comma-separated columns to return (repeatable)")
+	flags.Var(&spec.wheres, "where", "filter such as 'total>100' or 'status = paid' (repeatable)")
+	flags.Var(&spec.orders, "order", "column to order by, as column[:asc|desc] (repeatable)")
+	flags.IntVar(&spec.limit, "limit", 0, "maximum number of rows (0 = server default)")
+	flags.IntVar(&spec.offset, "offset", 0, "number of rows to skip")
+	positional, err := parse(flags, opts, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(positional[0], ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: table must be catalog.schema.table, got %q", utils.ErrInvalidRequest, positional[0])
+	}
+
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	dataDock, err := orDefault(*dataDockID, s.config.DataDockID, "-datadock (or HYPERFLUID_DATADOCK_ID)")
+	if err != nil {
+		return err
+	}
+	qb := s.client.DataDock(dataDock).Catalog(parts[0]).Schema(parts[1]).Table(parts[2])
+	columns, rows, err := spec.run(s.ctx, qb)
+	if err != nil {
+		return err
+	}
+	return s.out.print(columns, rows)
+}
+
+// querySpec is a query given on the command line or typed in the shell.
+type querySpec struct {
+	selects stringList // Comma-separated columns
+	wheres  stringList // Filters, see parseFilter
+	orders  stringList // column[:asc|desc]
+	limit   int
+	offset  int
+}
+
+// columns returns the selected columns, in order.
+func (q querySpec) columns() []string {
+	var columns []string
+	for _, value := range q.selects {
+		columns = append(columns, splitList(value)...)
+	}
+	return columns
+}
+
+// apply adds the spec's clauses to qb.
+func (q querySpec) apply(qb *fluent.QueryBuilder) (*fluent.QueryBuilder, error) {
+	if columns := q.columns(); len(columns) > 0 {
+		qb = qb.Select(columns...)
+	}
+	for _, value := range q.wheres {
+		column, operator, operand, err := parseFilter(value)
+		if err != nil {
+			return nil, err
+		}
+		qb = qb.Where(column, operator, operand)
+	}
+	for _, value := range q.orders {
+		column, direction, _ := strings.Cut(value, ":")
+		qb = qb.OrderBy(strings.TrimSpace(column), strings.TrimSpace(direction))
+	}
+	if q.limit > 0 {
+		qb = qb.Limit(q.limit)
+	}
+	if q.offset > 0 {
+		qb = qb.Offset(q.offset)
+	}
+	return qb, nil
+}
+
+// run executes the spec on the table of qb and returns the selected
+// columns and the rows.
+func (q querySpec) run(ctx context.Context, qb *fluent.QueryBuilder) ([]string, []map[string]any, error) {
+	qb, err := q.apply(qb)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := qb.Get(ctx)
+	if err := checkResponse(resp, err); err != nil {
+		return nil, nil, err
+	}
+	rows, err := toRows(resp.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.columns(), rows, nil
+}
+
+// filterOperators are the operators parseFilter recognizes; at the same
+// position the longest wins, so that ">=" is not read as ">".
+var filterOperators = []string{">=", "<=", "!=", "=", ">", "<", " LIKE ", " IN "}
+
+// parseFilter splits a --where value such as "total>=100" or
+// "name LIKE a%" at its first operator into column, operator and value.
+func parseFilter(filter string) (string, string, string, error) {
+	upper := strings.ToUpper(filter)
+	at, found := -1, ""
+	for _, operator := range filterOperators {
+		if i := strings.Index(upper, operator); i > 0 && (at < 0 || i < at) {
+			at, found = i, operator
+		}
+	}
+	if at < 0 || strings.TrimSpace(filter[:at]) == "" {
+		return "", "", "", fmt.Errorf("%w: invalid filter %q (want column<op>value, op one of = != > < >= <= LIKE IN)", utils.ErrInvalidRequest, filter)
+	}
+	return strings.TrimSpace(filter[:at]), strings.TrimSpace(found), strings.TrimSpace(filter[at+len(found):]), nil
+}
+
+func search(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	dataDockID := flags.String("datadock", "", "data dock (default HYPERFLUID_DATADOCK_ID)")
+	catalog := flags.String("catalog", "", "catalog of the indexed table (required)")
+	schema := flags.String("schema", "", "schema of the indexed table (required)")
+	table := flags.String("table", "", "indexed table (required)")
+	columns := flags.String("columns", "", "comma-separated columns to search")
+	limit := flags.Int("limit", 20, "maximum number of results")
+	positional, err := parse(flags, opts, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	sb := s.client.Search().Query(positional[0]).Catalog(*catalog).Schema(*schema).Table(*table).Limit(*limit)
+	if *dataDockID != "" {
+		sb = sb.DataDock(*dataDockID)
+	}
+	if cols := splitList(*columns); len(cols) > 0 {
+		sb = sb.Columns(cols...)
+	}
+	results, err := sb.Execute(s.ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]map[string]any, 0, len(results.Results))
+	for _, result := range results.Results {
+		rows = append(rows, map[string]any{
+			"score":      result.Score,
+			"name":       result.Record.Name,
+			"summary":    result.Record.Summary,
+			"categories": result.Record.Categories,
+			"file":       result.Record.OriginalFile.OriginalFilePath,
+		})
+	}
+	return s.out.print([]string{"score", "name", "summary", "file"}, rows)
+}
+
+func s3List(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	positional, err := parse(flags, opts, args, 1, 2)
+	if err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	s3, err := s.client.S3()
+	if err != nil {
+		return err
+	}
+	var prefix string
+	if len(positional) == 2 {
+		prefix = positional[1]
+	}
+	resp, err := s3.Bucket(positional[0]).List(s.ctx, prefix)
+	if err := checkResponse(resp, err); err != nil {
+		return err
+	}
+	data, _ := resp.GetDataAsMap()
+	return s.out.printValues([]string{"key", "size", "last_modified"}, data["objects"])
+}
+
+func s3Get(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	output := flags.String("out", "", "file to write the object to (default stdout)")
+	positional, err := parse(flags, opts, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	s3, err := s.client.S3()
+	if err != nil {
+		return err
+	}
+	object, err := s3.Bucket(positional[0]).Key(positional[1]).Get(s.ctx)
+	if err != nil {
+		return err
+	}
+	defer object.Body.Close()
+
+	if *output == "" {
+		_, err = io.Copy(stdout, object.Body)
+		return err
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(file, object.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d bytes to %s\n", written, *output)
+	return nil
+}
+
+// checkResponse turns a failed response into an error.
+func checkResponse(resp *utils.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp.Status != utils.StatusOK {
+		return fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	return nil
+}
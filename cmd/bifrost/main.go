@@ -0,0 +1,190 @@
+// Command bifrost is a command-line client for the Bifrost API, built on the
+// SDK. Its subcommands follow the progressive hierarchy:
+//
+//	bifrost org harbors list
+//	bifrost org datadocks list -status sleeping
+//	bifrost harbor datadocks list <harbor-id>
+//	bifrost harbor datadocks create <harbor-id> -name pg -type Trino -config '{"host":"db"}'
+//	bifrost datadock wakeup|sleep|refresh|catalog [datadock-id]
+//	bifrost query sales.public.orders --select id,total --where 'total>100' --order total:desc --limit 10
+//	bifrost search "machine learning" -catalog docs -schema public -table documents
+//	bifrost s3 ls <bucket> [prefix]
+//	bifrost s3 get <bucket> <key> [-out file]
+//	bifrost shell [-datadock id]
+//
+// The connection comes from a profile of the profiles file (-profile, see
+// utils.ConfigurationFromProfile) on top of the HYPERFLUID_*, KEYCLOAK_* and
+// MINIO_* environment variables, or a .env file. Results are printed with
+// -o table (default), json or csv.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const usage = `usage: bifrost <command> [flags]
+
+commands:
+  org harbors list            list the harbors of the organization
+  org datadocks list          list the data docks of the organization
+  harbor datadocks list       list the data docks of a harbor
+  harbor datadocks create     create a data dock in a harbor
+  datadock get                show a data dock
+  datadock wakeup             wake a data dock up
+  datadock sleep              put a data dock to sleep
+  datadock refresh            refresh the catalog of a data dock
+  datadock catalog            list the columns of a data dock's catalog
+  query                       query a table
+  search                      full-text search
+  shell                       explore catalogs and query tables interactively
+  s3 ls                       list objects of a bucket
+  s3 get                      download an object
+
+Run "bifrost <command> -h" for the flags of a command.`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "bifrost:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	_ = godotenv.Load()
+
+	path, rest := commandPath(args)
+	handler, ok := commands[path]
+	if !ok {
+		if path != "" {
+			fmt.Fprintf(os.Stderr, "bifrost: unknown command %q\n\n", path)
+		}
+		fmt.Fprintln(os.Stderr, usage)
+		return flag.ErrHelp
+	}
+	return handler(path, rest, stdout)
+}
+
+// commandPath splits args into the longest known command and its arguments.
+func commandPath(args []string) (string, []string) {
+	for n := min(len(args), 3); n > 0; n-- {
+		path := strings.Join(args[:n], " ")
+		if _, ok := commands[path]; ok {
+			return path, args[n:]
+		}
+	}
+	return strings.Join(args[:min(len(args), 1)], " "), nil
+}
+
+// options are the flags shared by every command.
+type options struct {
+	profile string
+	output  string
+	timeout time.Duration
+}
+
+func newFlags(path string) (*flag.FlagSet, *options) {
+	flags := flag.NewFlagSet("bifrost "+path, flag.ContinueOnError)
+	opts := &options{}
+	flags.StringVar(&opts.profile, "profile", "", "profile of the profiles file (default HYPERFLUID_PROFILE, then the file's default)")
+	flags.StringVar(&opts.output, "o", formatTable, "output format: table, json or csv")
+	flags.DurationVar(&opts.timeout, "timeout", time.Minute, "timeout of the command")
+	return flags, opts
+}
+
+// parse parses flags placed before, between or after the positional
+// arguments and checks their number.
+func parse(flags *flag.FlagSet, opts *options, args []string, minArgs, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if err := validFormat(opts.output); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(positional) < minArgs:
+		return nil, fmt.Errorf("%s: missing arguments", flags.Name())
+	case len(positional) > maxArgs:
+		return nil, fmt.Errorf("%s: unexpected arguments %q", flags.Name(), positional[maxArgs:])
+	}
+	return positional, nil
+}
+
+// session is what a command runs with once its flags are parsed.
+type session struct {
+	ctx    context.Context
+	client *sdk.Client
+	config utils.Configuration
+	out    printer
+}
+
+func (o *options) session(stdout io.Writer) (*session, context.CancelFunc, error) {
+	config, err := utils.ConfigurationFromProfile(o.profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	return &session{
+		ctx:    ctx,
+		client: sdk.NewClient(config),
+		config: config,
+		out:    printer{w: stdout, format: o.output},
+	}, cancel, nil
+}
+
+// orDefault returns value, or fallback when value is empty. It fails
+// naming what is missing when both are empty.
+func orDefault(value, fallback, what string) (string, error) {
+	if value == "" {
+		value = fallback
+	}
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is required", utils.ErrInvalidConfiguration, what)
+	}
+	return value, nil
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// newServer starts an API server answering every request with handler and
+// points the environment configuration at it.
+func newServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("BIFROST_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv("HYPERFLUID_PROFILE", "")
+	t.Setenv("HYPERFLUID_BASE_URL", server.URL)
+	t.Setenv("HYPERFLUID_TOKEN", "token")
+	t.Setenv("HYPERFLUID_ORG_ID", "acme")
+	t.Setenv("HYPERFLUID_DATADOCK_ID", "dock-1")
+}
+
+func TestQuery(t *testing.T) {
+	newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dock-1/openapi/sales/public/orders" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("select") != "id,total" || query.Get("total[>=]") != "100" ||
+			query.Get("status[=]") != "paid" || query.Get("order") != "total.desc" || query.Get("_limit") != "2" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"total": 250.5, "id": 1}, {"total": 100, "id": 2, "note": null}]`))
+	})
+
+	var out bytes.Buffer
+	err := run([]string{"query", "sales.public.orders",
+		"--select", "id,total", "--where", "total>=100", "--where", "status = paid",
+		"--order", "total:desc", "--limit", "2", "-o", "csv"}, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "id,total\n1,250.5\n2,100\n"; out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
+
+func TestOrgHarborsList(t *testing.T) {
+	newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme/harbors" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"harbors": [{"id": "h1", "name": "prod", "created_at": "2026-01-02T03:04:05Z"}]}`))
+	})
+
+	var out bytes.Buffer
+	if err := run([]string{"org", "harbors", "list"}, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[0] != "ID" || strings.Fields(lines[1])[1] != "prod" {
+		t.Errorf("Unexpected table:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"org", "harbors", "list", "-o", "json"}, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var harbors []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &harbors); err != nil || len(harbors) != 1 || harbors[0]["id"] != "h1" {
+		t.Errorf("Unexpected JSON %s (%v)", out.String(), err)
+	}
+}
+
+func TestDataDockCatalog(t *testing.T) {
+	newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data-docks/dock-2/catalog" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [
+			{"table_name": "orders", "columns": [{"column_name": "id", "data_type": "bigint", "is_nullable": "NO"}]},
+			{"table_name": "customers", "columns": [{"column_name": "name", "data_type": "varchar"}]}
+		]}]}]}`))
+	})
+
+	var out bytes.Buffer
+	if err := run([]string{"datadock", "catalog", "dock-2", "-table", "orders", "-o", "csv"}, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "catalog,schema,table,column,data_type,nullable\nsales,public,orders,id,bigint,false\n"; out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s", r.URL)
+	})
+
+	if err := run([]string{"harbor", "nope"}, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for an unknown command")
+	}
+	if err := run([]string{"query", "orders"}, &bytes.Buffer{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for a table without catalog and schema, got %v", err)
+	}
+	if err := run([]string{"query", "a.b.c", "--where", "nope"}, &bytes.Buffer{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an invalid filter, got %v", err)
+	}
+	if err := run([]string{"org", "harbors", "list", "-o", "xml"}, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for an invalid output format")
+	}
+	if err := run([]string{"harbor", "datadocks", "create", "h1", "-name", "pg"}, &bytes.Buffer{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a connection type, got %v", err)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter, column, operator, value string
+	}{
+		{"total>=100", "total", ">=", "100"},
+		{"status = paid", "status", "=", "paid"},
+		{"a!=b", "a", "!=", "b"},
+		{"url LIKE %a=b%", "url", "LIKE", "%a=b%"},
+		{"name like Jo%", "name", "LIKE", "Jo%"},
+		{"id IN 1,2,3", "id", "IN", "1,2,3"},
+	}
+	for _, test := range tests {
+		column, operator, value, err := parseFilter(test.filter)
+		if err != nil || column != test.column || operator != test.operator || value != test.value {
+			t.Errorf("parseFilter(%q) = %q %q %q %v", test.filter, column, operator, value, err)
+		}
+	}
+}
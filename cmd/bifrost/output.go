@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Output formats accepted by -o.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+func validFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	}
+	return fmt.Errorf("invalid output format %q (want table, json or csv)", format)
+}
+
+// printer writes command results as rows in the format chosen with -o.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// print writes rows restricted to columns, in that order. JSON output keeps
+// every field of the rows; without columns, table and CSV output use the
+// union of the rows' fields in alphabetical order.
+func (p printer) print(columns []string, rows []map[string]any) error {
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+
+	if len(columns) == 0 {
+		columns = columnsOf(rows)
+	}
+	if p.format == formatCSV {
+		writer := csv.NewWriter(p.w)
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.Write(cells(columns, row, false)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	writer := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(cells(columns, row, true), "\t"))
+	}
+	return writer.Flush()
+}
+
+// printValues prints a value (a struct, a map or a slice of them) as rows.
+func (p printer) printValues(columns []string, value any) error {
+	rows, err := toRows(value)
+	if err != nil {
+		return err
+	}
+	return p.print(columns, rows)
+}
+
+// toRows converts a struct, map or slice of them into rows, using their
+// JSON field names.
+func toRows(value any) ([]map[string]any, error) {
+	raw := bytes.TrimSpace(utils.JsonMarshal(value))
+	if len(raw) > 0 && raw[0] != '[' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var rows []map[string]any
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: results are not rows: %w", utils.ErrInvalidResponse, err)
+	}
+	return rows, nil
+}
+
+// columnsOf returns the fields of rows in alphabetical order.
+func columnsOf(rows []map[string]any) []string {
+	seen := map[string]bool{}
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// cells formats the values of row for columns. Table cells are kept on
+// one line.
+func cells(columns []string, row map[string]any, oneLine bool) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = formatValue(row[column])
+		if oneLine {
+			values[i] = strings.NewReplacer("\n", " ", "\r", " ", "\t", " ").Replace(values[i])
+		}
+	}
+	return values
+}
+
+// formatValue renders a scalar as text and anything else as JSON.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return string(utils.JsonMarshal(value))
+}
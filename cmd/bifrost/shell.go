@@ -0,0 +1,613 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const shellHelp = `commands:
+  use datadock <id>          explore another data dock
+  ls [path]                  list catalogs, schemas, tables or columns
+  cd [path]                  move to a catalog, schema or table ("..", "/", "sales/public")
+  pwd                        show the current data dock and path
+  describe [table]           show the columns of a table
+  query [table] [select <cols>] [where <filter>]... [order <col>[:desc]]... [limit <n>] [offset <n>]
+                             query a table, e.g. query orders select id,total where total > 100 limit 10
+  help                       show this help
+  exit                       leave the shell
+
+Tab completes commands, catalog, schema and table names, and columns.`
+
+// shellCommands are the commands the shell completes.
+var shellCommands = []string{"cd", "describe", "exit", "help", "ls", "pwd", "query", "use"}
+
+// queryKeywords start the clauses of a shell query.
+var queryKeywords = []string{"select", "where", "order", "limit", "offset"}
+
+// errExit ends the shell.
+var errExit = errors.New("exit")
+
+// shell is an interactive session exploring the catalogs of a data dock and
+// querying its tables. Catalog metadata comes from the client's catalog
+// cache, so navigation and completion do not refetch it on every key.
+type shell struct {
+	client   *sdk.Client
+	timeout  time.Duration // Bound of each command
+	out      printer
+	pageSize int                                 // Lines per page of results, 0 to print them at once
+	prompt   func(prompt string) (string, error) // Reads the pager's answer
+
+	dataDockID string
+	path       []string // Catalog, schema and table, as deep as the user went
+}
+
+func shellCommand(path string, args []string, stdout io.Writer) error {
+	flags, opts := newFlags(path)
+	dataDockID := flags.String("datadock", "", "data dock to explore (default HYPERFLUID_DATADOCK_ID)")
+	if _, err := parse(flags, opts, args, 0, 0); err != nil {
+		return err
+	}
+	s, cancel, err := opts.session(stdout)
+	if err != nil {
+		return err
+	}
+	cancel() // Each shell command gets its own timeout
+
+	sh := &shell{client: newShellClient(s.config), timeout: opts.timeout, out: s.out, dataDockID: s.config.DataDockID}
+	if *dataDockID != "" {
+		sh.dataDockID = *dataDockID
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return sh.runScript(os.Stdin)
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+	return sh.runTerminal(fd)
+}
+
+// newShellClient creates the shell's client, with the catalog cache enabled
+// unless the configuration disables it.
+func newShellClient(config utils.Configuration) *sdk.Client {
+	if config.CatalogCacheTTL == 0 {
+		config.CatalogCacheTTL = utils.DefaultCatalogCacheTTL
+	}
+	return sdk.NewClient(config)
+}
+
+// runTerminal reads commands from the terminal, with history, completion
+// and paging.
+func (sh *shell) runTerminal(fd int) error {
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, sh.promptText())
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		newLine, newPos, candidates := sh.complete(line, pos)
+		if len(candidates) > 1 {
+			fmt.Fprintln(terminal, strings.Join(candidates, "  "))
+		}
+		return newLine, newPos, true
+	}
+	sh.out.w = terminal
+	sh.prompt = func(prompt string) (string, error) {
+		terminal.SetPrompt(prompt)
+		defer terminal.SetPrompt(sh.promptText())
+		return terminal.ReadLine()
+	}
+	if _, height, err := term.GetSize(fd); err == nil && height > 3 {
+		sh.pageSize = height - 2
+	}
+
+	fmt.Fprintln(terminal, `Type "help" for the commands, Tab to complete.`)
+	for {
+		line, err := terminal.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := sh.exec(line); errors.Is(err, errExit) {
+			return nil
+		} else if err != nil {
+			fmt.Fprintln(terminal, "error:", err)
+		}
+		terminal.SetPrompt(sh.promptText())
+	}
+}
+
+// runScript runs the commands of a non-interactive input, one per line,
+// and stops at the first error.
+func (sh *shell) runScript(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		if err := sh.exec(scanner.Text()); errors.Is(err, errExit) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (sh *shell) promptText() string {
+	if sh.dataDockID == "" {
+		return "bifrost> "
+	}
+	return fmt.Sprintf("bifrost %s:/%s> ", sh.dataDockID, strings.Join(sh.path, "/"))
+}
+
+// exec runs one command line.
+func (sh *shell) exec(line string) error {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sh.timeout)
+	defer cancel()
+
+	command, args := words[0], words[1:]
+	switch command {
+	case "help":
+		_, err := fmt.Fprintln(sh.out.w, shellHelp)
+		return err
+	case "exit", "quit":
+		return errExit
+	case "use":
+		return sh.use(ctx, args)
+	}
+
+	if sh.dataDockID == "" {
+		return fmt.Errorf("%w: no data dock selected, run \"use datadock <id>\"", utils.ErrInvalidRequest)
+	}
+	switch command {
+	case "pwd":
+		_, err := fmt.Fprintf(sh.out.w, "%s:/%s\n", sh.dataDockID, strings.Join(sh.path, "/"))
+		return err
+	case "ls":
+		return sh.ls(ctx, args)
+	case "cd":
+		return sh.cd(ctx, args)
+	case "describe":
+		return sh.describe(ctx, args)
+	case "query":
+		return sh.query(ctx, args)
+	}
+	return fmt.Errorf("%w: unknown command %q, see \"help\"", utils.ErrInvalidRequest, command)
+}
+
+func (sh *shell) use(ctx context.Context, args []string) error {
+	if len(args) == 2 && args[0] == "datadock" {
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("%w: usage: use datadock <id>", utils.ErrInvalidRequest)
+	}
+	previous, previousPath := sh.dataDockID, sh.path
+	sh.dataDockID, sh.path = args[0], nil
+	if _, err := sh.catalogs(ctx); err != nil {
+		sh.dataDockID, sh.path = previous, previousPath
+		return err
+	}
+	return nil
+}
+
+func (sh *shell) ls(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("%w: usage: ls [path]", utils.ErrInvalidRequest)
+	}
+	path := sh.path
+	if len(args) == 1 {
+		path = sh.resolve(args[0])
+	}
+	if len(path) >= 3 {
+		return sh.describe(ctx, args)
+	}
+
+	var names []string
+	var err error
+	dataDock := sh.dataDock()
+	switch len(path) {
+	case 0:
+		var catalogs []builders.Catalog
+		catalogs, err = dataDock.Catalogs(ctx)
+		for _, catalog := range catalogs {
+			names = append(names, catalog.Name)
+		}
+	case 1:
+		names, err = dataDock.Catalog(path[0]).ListSchemas(ctx)
+	case 2:
+		names, err = dataDock.Catalog(path[0]).Schema(path[1]).ListTables(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Fprintln(sh.out.w, name)
+	}
+	return nil
+}
+
+func (sh *shell) cd(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("%w: usage: cd [path]", utils.ErrInvalidRequest)
+	}
+	path := []string(nil)
+	if len(args) == 1 {
+		path = sh.resolve(args[0])
+	}
+	if err := sh.check(ctx, path); err != nil {
+		return err
+	}
+	sh.path = path
+	return nil
+}
+
+func (sh *shell) describe(ctx context.Context, args []string) error {
+	table, err := sh.table(args)
+	if err != nil {
+		return err
+	}
+	metadata, err := sh.dataDock().Catalog(table[0]).Schema(table[1]).Table(table[2]).Describe(ctx)
+	if err != nil {
+		return err
+	}
+	return sh.page([]string{"column_name", "data_type", "is_nullable", "comment"}, metadata.Columns)
+}
+
+func (sh *shell) query(ctx context.Context, args []string) error {
+	var target []string
+	if len(args) > 0 && !isQueryKeyword(args[0]) {
+		target, args = args[:1], args[1:]
+	}
+	table, err := sh.table(target)
+	if err != nil {
+		return err
+	}
+	spec, err := parseQuery(args)
+	if err != nil {
+		return err
+	}
+
+	qb := sh.client.DataDock(sh.dataDockID).Catalog(table[0]).Schema(table[1]).Table(table[2])
+	columns, rows, err := spec.run(ctx, qb)
+	if err != nil {
+		return err
+	}
+	return sh.page(columns, rows)
+}
+
+// parseQuery reads the clauses of a shell query. A clause runs until the
+// next keyword, so filters may contain spaces ("where status = paid").
+func parseQuery(words []string) (querySpec, error) {
+	var spec querySpec
+	for len(words) > 0 {
+		keyword := strings.ToLower(words[0])
+		if !isQueryKeyword(keyword) {
+			return spec, fmt.Errorf("%w: expected one of %s, got %q", utils.ErrInvalidRequest, strings.Join(queryKeywords, ", "), words[0])
+		}
+		end := 1
+		for end < len(words) && !isQueryKeyword(words[end]) {
+			end++
+		}
+		value := strings.Join(words[1:end], " ")
+		words = words[end:]
+		if keyword == "order" {
+			value = strings.TrimPrefix(value, "by ")
+		}
+		if value == "" {
+			return spec, fmt.Errorf("%w: %s needs a value", utils.ErrInvalidRequest, keyword)
+		}
+
+		switch keyword {
+		case "select":
+			spec.selects = append(spec.selects, value)
+		case "where":
+			spec.wheres = append(spec.wheres, value)
+		case "order":
+			column, direction, _ := strings.Cut(value, " ")
+			if direction != "" {
+				value = column + ":" + direction
+			}
+			spec.orders = append(spec.orders, value)
+		case "limit", "offset":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return spec, fmt.Errorf("%w: %s must be a positive number, got %q", utils.ErrInvalidRequest, keyword, value)
+			}
+			if keyword == "limit" {
+				spec.limit = n
+			} else {
+				spec.offset = n
+			}
+		}
+	}
+	return spec, nil
+}
+
+func isQueryKeyword(word string) bool {
+	word = strings.ToLower(word)
+	for _, keyword := range queryKeywords {
+		if word == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// page prints rows, a page at a time when the shell runs in a terminal.
+func (sh *shell) page(columns []string, value any) error {
+	var buffer bytes.Buffer
+	if err := (printer{w: &buffer, format: sh.out.format}).printValues(columns, value); err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(buffer.String(), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if sh.pageSize <= 0 || sh.prompt == nil || len(lines) <= sh.pageSize {
+		_, err := io.WriteString(sh.out.w, buffer.String())
+		return err
+	}
+
+	// Table and CSV output repeat their header on every page.
+	header, body := "", lines
+	if sh.out.format != formatJSON {
+		header, body = lines[0], lines[1:]
+	}
+	rows := sh.pageSize - 1
+	for start := 0; start < len(body); start += rows {
+		end := min(start+rows, len(body))
+		if _, err := io.WriteString(sh.out.w, header+strings.Join(body[start:end], "")); err != nil {
+			return err
+		}
+		if end == len(body) {
+			break
+		}
+		answer, err := sh.prompt(fmt.Sprintf("-- %d/%d lines, Enter for more, q to stop -- ", end, len(body)))
+		if err != nil || strings.HasPrefix(strings.TrimSpace(answer), "q") {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (sh *shell) dataDock() *progressive.DataDockBuilder {
+	return sh.client.OrgFromConfig().Harbor("").DataDock(sh.dataDockID)
+}
+
+func (sh *shell) catalogs(ctx context.Context) ([]builders.Catalog, error) {
+	return sh.dataDock().Catalogs(ctx)
+}
+
+// resolve returns the path designated by arg, relative to the current path
+// unless it starts with "/". Segments are separated by "/"; ".." goes up.
+func (sh *shell) resolve(arg string) []string {
+	var path []string
+	if !strings.HasPrefix(arg, "/") {
+		path = append(path, sh.path...)
+	}
+	for _, segment := range strings.Split(arg, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		default:
+			path = append(path, segment)
+		}
+	}
+	return path
+}
+
+// check verifies that path exists in the catalog metadata.
+func (sh *shell) check(ctx context.Context, path []string) error {
+	if len(path) > 3 {
+		return fmt.Errorf("%w: %s is deeper than a table", utils.ErrInvalidRequest, strings.Join(path, "/"))
+	}
+	if len(path) == 0 {
+		return nil
+	}
+	catalogs, err := sh.catalogs(ctx)
+	if err != nil {
+		return err
+	}
+	catalog, ok := builders.FindCatalog(catalogs, path[0])
+	if !ok {
+		return fmt.Errorf("%w: catalog %q", utils.ErrNotFound, path[0])
+	}
+	if len(path) == 1 {
+		return nil
+	}
+	schema, ok := catalog.Schema(path[1])
+	if !ok {
+		return fmt.Errorf("%w: schema %q in catalog %q", utils.ErrNotFound, path[1], path[0])
+	}
+	if len(path) == 3 {
+		if _, ok := schema.Table(path[2]); !ok {
+			return fmt.Errorf("%w: table %q in %s.%s", utils.ErrNotFound, path[2], path[0], path[1])
+		}
+	}
+	return nil
+}
+
+// table returns the table designated by the optional argument: a table
+// name in the current schema, a path, or the current table.
+func (sh *shell) table(args []string) ([]string, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("%w: expected a single table", utils.ErrInvalidRequest)
+	}
+	path := sh.path
+	if len(args) == 1 {
+		path = sh.resolve(args[0])
+	}
+	if len(path) != 3 {
+		return nil, fmt.Errorf("%w: not a table: /%s (cd to a schema or give catalog/schema/table)", utils.ErrInvalidRequest, strings.Join(path, "/"))
+	}
+	return path, nil
+}
+
+// complete completes the word before pos in line. It returns the new line
+// and cursor position, and the candidates when there are several.
+func (sh *shell) complete(line string, pos int) (string, int, []string) {
+	before := line[:pos]
+	words := strings.Fields(before)
+	word := ""
+	if len(words) > 0 && !strings.HasSuffix(before, " ") {
+		word, words = words[len(words)-1], words[:len(words)-1]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sh.timeout)
+	defer cancel()
+
+	prefix, candidates := "", sh.candidates(ctx, words, word)
+	// Column lists complete after their last comma, paths after their last slash.
+	if i := strings.LastIndexAny(word, ",/"); i >= 0 {
+		prefix, word = word[:i+1], word[i+1:]
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return line, pos, nil
+	}
+	completion := commonPrefix(matches)
+	if len(matches) == 1 {
+		// Catalogs and schemas are followed by their children, the rest by the next word.
+		if len(words) == 1 && (isPathCommand(words[0]) || words[0] == "query" && !isQueryKeyword(completion)) &&
+			len(sh.resolve(prefix+completion)) < 3 {
+			completion += "/"
+		} else {
+			completion += " "
+		}
+	}
+	insert := completion[len(word):]
+	newLine := before + insert + line[pos:]
+	if len(matches) == 1 {
+		return newLine, pos + len(insert), nil
+	}
+	return newLine, pos + len(insert), matches
+}
+
+func isPathCommand(command string) bool {
+	return command == "cd" || command == "ls" || command == "describe"
+}
+
+// candidates returns the completions of word after the command words.
+func (sh *shell) candidates(ctx context.Context, words []string, word string) []string {
+	if len(words) == 0 {
+		return shellCommands
+	}
+	switch command := words[0]; {
+	case command == "use" && len(words) == 1:
+		return []string{"datadock"}
+	case isPathCommand(command) && len(words) == 1:
+		return sh.children(ctx, word)
+	case command == "query" && len(words) == 1:
+		return append(sh.children(ctx, word), queryKeywords...)
+	case command == "query":
+		table := sh.path
+		if !isQueryKeyword(words[1]) {
+			table = sh.resolve(words[1])
+		}
+		switch previous := strings.ToLower(words[len(words)-1]); {
+		case previous == "select" || previous == "where" || previous == "order" || previous == "by" ||
+			strings.HasSuffix(previous, ","):
+			return sh.columns(ctx, table)
+		}
+		return queryKeywords
+	}
+	return nil
+}
+
+// children returns the names below the directory part of a path being
+// typed: catalogs, schemas or tables.
+func (sh *shell) children(ctx context.Context, word string) []string {
+	dir := ""
+	if i := strings.LastIndex(word, "/"); i >= 0 {
+		dir = word[:i+1]
+	}
+	path := sh.resolve(dir)
+	if dir == "" {
+		path = sh.path
+	}
+	catalogs, err := sh.catalogs(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	switch len(path) {
+	case 0:
+		for _, catalog := range catalogs {
+			names = append(names, catalog.Name)
+		}
+	case 1:
+		if catalog, ok := builders.FindCatalog(catalogs, path[0]); ok {
+			names = catalog.SchemaNames()
+		}
+	case 2:
+		if catalog, ok := builders.FindCatalog(catalogs, path[0]); ok {
+			if schema, ok := catalog.Schema(path[1]); ok {
+				names = schema.TableNames()
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// columns returns the column names of a table, or nil if it is unknown.
+func (sh *shell) columns(ctx context.Context, path []string) []string {
+	if len(path) != 3 {
+		return nil
+	}
+	catalogs, err := sh.catalogs(ctx)
+	if err != nil {
+		return nil
+	}
+	table, err := builders.FindTable(catalogs, path[0], path[1], path[2])
+	if err != nil {
+		return nil
+	}
+	return table.ColumnNames()
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const shellCatalog = `{"catalogs": [
+	{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [
+		{"table_name": "orders", "columns": [
+			{"column_name": "id", "data_type": "bigint", "is_nullable": false},
+			{"column_name": "total", "data_type": "double"},
+			{"column_name": "status", "data_type": "varchar"}
+		]},
+		{"table_name": "customers", "columns": [{"column_name": "name", "data_type": "varchar"}]}
+	]}]},
+	{"catalog_name": "crm", "schemas": []}
+]}`
+
+// newTestShell starts a shell on data dock dock-1 against a server that
+// serves shellCatalog and answers queries with rows.
+func newTestShell(t *testing.T, rows string, queries *[]string) (*shell, *bytes.Buffer, *atomic.Int32) {
+	t.Helper()
+	var catalogFetches atomic.Int32
+	newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/data-docks/dock-1/catalog" {
+			catalogFetches.Add(1)
+			_, _ = w.Write([]byte(shellCatalog))
+			return
+		}
+		if queries != nil {
+			*queries = append(*queries, r.URL.Path+"?"+r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(rows))
+	})
+
+	var out bytes.Buffer
+	return &shell{
+		client:     newShellClient(utils.ConfigurationFromEnv()),
+		timeout:    time.Minute,
+		out:        printer{w: &out, format: formatTable},
+		dataDockID: "dock-1",
+	}, &out, &catalogFetches
+}
+
+func TestShell_Navigation(t *testing.T) {
+	sh, out, catalogFetches := newTestShell(t, `[]`, nil)
+
+	steps := []struct {
+		line, want string
+	}{
+		{"ls", "sales\ncrm\n"},
+		{"cd sales/public", ""},
+		{"pwd", "dock-1:/sales/public\n"},
+		{"ls", "orders\ncustomers\n"},
+		{"cd ../..", ""},
+		{"ls /sales", "public\n"},
+	}
+	for _, step := range steps {
+		out.Reset()
+		if err := sh.exec(step.line); err != nil {
+			t.Fatalf("%s: expected no error, got %v", step.line, err)
+		}
+		if out.String() != step.want {
+			t.Errorf("%s: expected %q, got %q", step.line, step.want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := sh.exec("describe sales/public/orders"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "COLUMN_NAME") || !strings.Contains(out.String(), "total") {
+		t.Errorf("Unexpected description:\n%s", out.String())
+	}
+
+	if err := sh.exec("cd sales/nope"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := sh.exec("describe"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest outside a table, got %v", err)
+	}
+	if err := sh.exec("exit"); !errors.Is(err, errExit) {
+		t.Errorf("Expected errExit, got %v", err)
+	}
+	if n := catalogFetches.Load(); n != 1 {
+		t.Errorf("Expected the catalog to be fetched once, got %d", n)
+	}
+}
+
+func TestShell_Query(t *testing.T) {
+	var queries []string
+	sh, out, _ := newTestShell(t, `[{"id": 1, "total": 120}, {"id": 2, "total": 300}, {"id": 3, "total": 150}]`, &queries)
+	if err := sh.exec("cd sales/public"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sh.exec("query orders select id,total where total > 100 where status = paid order by total desc limit 3"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := "/dock-1/openapi/sales/public/orders?_limit=3&order=total.desc&select=id%2Ctotal&status%5B%3D%5D=paid&total%5B%3E%5D=100"
+	if len(queries) != 1 || queries[0] != want {
+		t.Errorf("Expected %s, got %v", want, queries)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || strings.Fields(lines[0])[0] != "ID" || strings.Fields(lines[0])[1] != "TOTAL" {
+		t.Errorf("Unexpected table:\n%s", out.String())
+	}
+
+	// Pages of two lines: the header and one row, until the user stops.
+	out.Reset()
+	var prompts int
+	sh.pageSize = 2
+	sh.prompt = func(string) (string, error) {
+		prompts++
+		return []string{"", "q"}[prompts-1], nil
+	}
+	if err := sh.exec("query orders select id"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if prompts != 2 || strings.Count(out.String(), "ID") != 2 || strings.Contains(out.String(), "3") {
+		t.Errorf("Unexpected paging after %d prompts:\n%s", prompts, out.String())
+	}
+
+	if err := sh.exec("query orders limit many"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestShell_Complete(t *testing.T) {
+	sh, _, _ := newTestShell(t, `[]`, nil)
+
+	tests := []struct {
+		line, want string
+		candidates int
+	}{
+		{"qu", "query ", 0},
+		{"cd sa", "cd sales/", 0},
+		{"cd sales/public/or", "cd sales/public/orders ", 0},
+		{"ls ", "ls ", 2},
+		{"query sales/public/orders select i", "query sales/public/orders select id ", 0},
+		{"query sales/public/orders select id,t", "query sales/public/orders select id,total ", 0},
+		{"query sales/public/orders where s", "query sales/public/orders where status ", 0},
+		{"query sales/public/orders select id l", "query sales/public/orders select id limit ", 0},
+		{"use d", "use datadock ", 0},
+	}
+	for _, test := range tests {
+		line, pos, candidates := sh.complete(test.line, len(test.line))
+		if line != test.want || pos != len(test.want) || len(candidates) != test.candidates {
+			t.Errorf("complete(%q) = %q, %d, %v", test.line, line, pos, candidates)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// wakeCall is an in-flight wake-up that concurrent requests wait on.
+type wakeCall struct {
+	done chan struct{}
+	err  error
+}
+
+// autoWakeKey marks requests made while waking a data dock up, so they
+// never trigger another wake-up.
+type autoWakeKey struct{}
+
+// sleepingErrorCode is the error code the API returns for requests to a
+// data dock that is asleep.
+const sleepingErrorCode = "data_dock_sleeping"
+
+// isDataDockSleeping recognizes the error the API returns for requests to a
+// data dock that is asleep: a 409, 423 or 503 whose JSON body has the
+// sleepingErrorCode in "code" (or "error_code"), or the data dock status
+// "sleeping" in "status". Free-form error messages are not inspected.
+func isDataDockSleeping(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusConflict, http.StatusLocked, http.StatusServiceUnavailable:
+	default:
+		return false
+	}
+
+	var fields map[string]any
+	if json.Unmarshal(body, &fields) != nil {
+		return false
+	}
+	for _, key := range []string{"code", "error_code"} {
+		if code, _ := fields[key].(string); strings.EqualFold(code, sleepingErrorCode) {
+			return true
+		}
+	}
+	status, _ := fields["status"].(string)
+	return progressive.DataDockStatus(status) == progressive.DataDockSleeping
+}
+
+// dataDockFromURL returns the data dock addressed by a data or management
+// endpoint ({base}/{id}/openapi/..., {base}/{id}/sql, {base}/data-docks/{id}/...).
+func (c *Client) dataDockFromURL(endpoint string) string {
+	path, ok := strings.CutPrefix(endpoint, strings.TrimSuffix(c.config.BaseURL, "/")+"/")
+	if !ok {
+		return ""
+	}
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return ""
+	}
+
+	var id string
+	switch {
+	case segments[0] == "data-docks":
+		id = segments[1]
+	case segments[1] == "openapi" || segments[1] == "sql":
+		id = segments[0]
+	}
+	if id, err := url.PathUnescape(id); err == nil {
+		return id
+	}
+	return ""
+}
+
+// doWithAutoWake retries a request once after waking its data dock up when
+// the first attempt failed with utils.ErrDataDockSleeping.
+func (c *Client) doWithAutoWake(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error) {
+	resp, err := c.doRequest(ctx, method, endpoint, body, headers)
+	if !c.config.AutoWakeDataDocks || !errors.Is(err, utils.ErrDataDockSleeping) || ctx.Value(autoWakeKey{}) != nil {
+		return resp, err
+	}
+	dataDockID := c.dataDockFromURL(endpoint)
+	if dataDockID == "" {
+		return resp, err
+	}
+
+	if wakeErr := c.wakeUp(ctx, dataDockID); wakeErr != nil {
+		return resp, fmt.Errorf("%w; auto-wake failed: %w", err, wakeErr)
+	}
+	return c.doRequest(ctx, method, endpoint, body, headers)
+}
+
+// wakeUp wakes a data dock up and waits until it is running. Concurrent
+// callers for the same data dock share one wake-up, which is not cancelled
+// when an individual caller gives up.
+func (c *Client) wakeUp(ctx context.Context, dataDockID string) error {
+	c.wakeMu.Lock()
+	call, ok := c.wakeCalls[dataDockID]
+	if !ok {
+		if c.wakeCalls == nil {
+			c.wakeCalls = map[string]*wakeCall{}
+		}
+		call = &wakeCall{done: make(chan struct{})}
+		c.wakeCalls[dataDockID] = call
+		go c.runWakeUp(context.WithoutCancel(ctx), dataDockID, call)
+	}
+	c.wakeMu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) runWakeUp(ctx context.Context, dataDockID string, call *wakeCall) {
+	timeout := c.config.AutoWakeTimeout
+	if timeout <= 0 {
+		timeout = utils.DefaultAutoWakeTimeout
+	}
+	dataDock := c.Org(c.config.OrgID).Harbor("").DataDock(dataDockID)
+	_, err := dataDock.WakeUpAndWait(context.WithValue(ctx, autoWakeKey{}, true), progressive.WaitOptions{Timeout: timeout, Interval: c.wakePollInterval})
+
+	c.wakeMu.Lock()
+	delete(c.wakeCalls, dataDockID)
+	call.err = err
+	close(call.done)
+	c.wakeMu.Unlock()
+}
@@ -0,0 +1,179 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// sleepingDataDock fakes a data dock that rejects queries while asleep and
+// reports "starting" for a few polls after a wake-up.
+type sleepingDataDock struct {
+	mu          sync.Mutex
+	asleep      bool
+	startPolls  int
+	wakeUps     atomic.Int32
+	queries     atomic.Int32
+	statusPolls atomic.Int32
+}
+
+func (d *sleepingDataDock) roundTrip(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	reply := func(code int, body string) (*http.Response, error) {
+		return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	switch {
+	case req.Method == "POST" && req.URL.Path == "/data-docks/dd/wake-up":
+		d.wakeUps.Add(1)
+		d.asleep = false
+		d.startPolls = 2
+		return reply(http.StatusAccepted, "")
+	case req.URL.Path == "/data-docks/dd":
+		d.statusPolls.Add(1)
+		status := "sleeping"
+		if !d.asleep {
+			status = "running"
+			if d.startPolls > 0 {
+				d.startPolls--
+				status = "starting"
+			}
+		}
+		return reply(http.StatusOK, `{"id": "dd", "status": "`+status+`"}`)
+	default:
+		d.queries.Add(1)
+		if d.asleep || d.startPolls > 0 {
+			return reply(http.StatusServiceUnavailable, `{"code": "data_dock_sleeping", "error": "data dock dd is sleeping"}`)
+		}
+		if strings.HasSuffix(req.URL.Path, "/sql") {
+			return reply(http.StatusOK, `{"columns": [{"name": "id"}], "rows": [[1]]}`)
+		}
+		return reply(http.StatusOK, `[{"id": 1}]`)
+	}
+}
+
+func newSleepingClient(autoWake bool) (*Client, *sleepingDataDock) {
+	dock := &sleepingDataDock{asleep: true}
+	client := NewClient(utils.Configuration{
+		Token:             "test-token",
+		BaseURL:           "https://test.example.com",
+		AutoWakeDataDocks: autoWake,
+		AutoWakeTimeout:   5 * time.Second,
+	})
+	client.httpClient = &http.Client{Transport: &mockRoundTripper{roundTripFunc: dock.roundTrip}}
+	client.wakePollInterval = time.Millisecond
+	return client, dock
+}
+
+func TestAutoWake_Disabled(t *testing.T) {
+	client, dock := newSleepingClient(false)
+	client.config.MaxRetries = 1
+
+	// The 503 goes through the usual retries instead of short-circuiting
+	_, err := client.DataDock("dd").Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err == nil || errors.Is(err, utils.ErrDataDockSleeping) {
+		t.Fatalf("Expected a retried server error, got %v", err)
+	}
+	if dock.wakeUps.Load() != 0 || dock.queries.Load() != 2 {
+		t.Errorf("Expected a retried query and no wake-up, got %d queries, %d wake-ups", dock.queries.Load(), dock.wakeUps.Load())
+	}
+}
+
+func TestIsDataDockSleeping(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   bool
+	}{
+		{http.StatusServiceUnavailable, `{"code": "data_dock_sleeping"}`, true},
+		{http.StatusConflict, `{"error_code": "DATA_DOCK_SLEEPING", "error": "asleep"}`, true},
+		{http.StatusLocked, `{"status": "sleeping"}`, true},
+		{http.StatusBadRequest, `{"code": "data_dock_sleeping"}`, false},
+		{http.StatusConflict, `{"error": "row locked while the job is sleeping"}`, false},
+		{http.StatusServiceUnavailable, `data dock is sleeping`, false},
+		{http.StatusServiceUnavailable, `{"status": 503, "code": "overloaded"}`, false},
+	}
+	for _, tt := range tests {
+		if got := isDataDockSleeping(tt.status, []byte(tt.body)); got != tt.want {
+			t.Errorf("isDataDockSleeping(%d, %s) = %v, want %v", tt.status, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestAutoWake_WakesUpAndRetries(t *testing.T) {
+	client, dock := newSleepingClient(true)
+
+	resp, err := client.DataDock("dd").Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, ok := resp.GetDataAsSlice(); !ok || len(data) != 1 {
+		t.Errorf("Unexpected data %v", resp.Data)
+	}
+	if dock.wakeUps.Load() != 1 || dock.queries.Load() != 2 || dock.statusPolls.Load() != 3 {
+		t.Errorf("Expected wake-up, 3 status polls and one retry, got %d wake-ups, %d polls, %d queries",
+			dock.wakeUps.Load(), dock.statusPolls.Load(), dock.queries.Load())
+	}
+
+	// SQL requests are recognized too
+	dock.asleep = true
+	if _, err := client.SQL("dd").Query(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dock.wakeUps.Load() != 2 {
+		t.Errorf("Expected a second wake-up, got %d", dock.wakeUps.Load())
+	}
+}
+
+func TestAutoWake_ConcurrentQueriesShareOneWakeUp(t *testing.T) {
+	client, dock := newSleepingClient(true)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.DataDock("dd").Catalog("c").Schema("s").Table("t").Get(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if dock.wakeUps.Load() != 1 {
+		t.Errorf("Expected concurrent queries to share one wake-up, got %d", dock.wakeUps.Load())
+	}
+}
+
+func TestDataDockFromURL(t *testing.T) {
+	client := NewClient(utils.Configuration{BaseURL: "https://test.example.com"})
+	tests := map[string]string{
+		"https://test.example.com/dd/openapi/c/s/t?_limit=1": "dd",
+		"https://test.example.com/dd/openapi/c/_batch":       "dd",
+		"https://test.example.com/my%20dock/sql":             "my dock",
+		"https://test.example.com/data-docks/dd/catalog":     "dd",
+		"https://test.example.com/api/search":                "",
+		"https://test.example.com/org/harbors":               "",
+		"https://other.example.com/dd/sql":                   "",
+	}
+	for endpoint, want := range tests {
+		if got := client.dataDockFromURL(endpoint); got != want {
+			t.Errorf("dataDockFromURL(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
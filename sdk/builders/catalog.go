@@ -0,0 +1,217 @@
+package builders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Catalog describes a catalog of a data dock, as returned by
+// GET /data-docks/{id}/catalog.
+type Catalog struct {
+	Name    string   `json:"catalog_name"`
+	Schemas []Schema `json:"schemas"`
+}
+
+// Schema describes a schema and its tables.
+type Schema struct {
+	Name   string  `json:"schema_name"`
+	Tables []Table `json:"tables"`
+}
+
+// Table describes a table and its columns.
+type Table struct {
+	Name    string   `json:"table_name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// Column describes a table column.
+type Column struct {
+	Name     string `json:"column_name"`
+	DataType string `json:"data_type"`
+	Nullable bool   `json:"is_nullable"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// UnmarshalJSON accepts is_nullable as a boolean or as "YES"/"NO"
+// (information_schema style). A missing is_nullable means nullable.
+func (c *Column) UnmarshalJSON(data []byte) error {
+	type plain Column
+	var raw struct {
+		plain
+		IsNullable json.RawMessage `json:"is_nullable"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = Column(raw.plain)
+
+	switch value := string(bytes.TrimSpace(raw.IsNullable)); strings.ToUpper(value) {
+	case "", "NULL", "TRUE", `"YES"`, `"TRUE"`:
+		c.Nullable = true
+	case "FALSE", `"NO"`, `"FALSE"`:
+		c.Nullable = false
+	default:
+		return fmt.Errorf("column %q: invalid is_nullable value %s", c.Name, value)
+	}
+	return nil
+}
+
+// Schema returns the schema with the given name.
+func (c *Catalog) Schema(name string) (*Schema, bool) {
+	for i := range c.Schemas {
+		if c.Schemas[i].Name == name {
+			return &c.Schemas[i], true
+		}
+	}
+	return nil, false
+}
+
+// SchemaNames returns the names of the catalog's schemas.
+func (c *Catalog) SchemaNames() []string {
+	names := make([]string, len(c.Schemas))
+	for i, schema := range c.Schemas {
+		names[i] = schema.Name
+	}
+	return names
+}
+
+// Table returns the table with the given name.
+func (s *Schema) Table(name string) (*Table, bool) {
+	for i := range s.Tables {
+		if s.Tables[i].Name == name {
+			return &s.Tables[i], true
+		}
+	}
+	return nil, false
+}
+
+// TableNames returns the names of the schema's tables.
+func (s *Schema) TableNames() []string {
+	names := make([]string, len(s.Tables))
+	for i, table := range s.Tables {
+		names[i] = table.Name
+	}
+	return names
+}
+
+// Column returns the column with the given name.
+func (t *Table) Column(name string) (*Column, bool) {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i], true
+		}
+	}
+	return nil, false
+}
+
+// ColumnNames returns the names of the table's columns, in table order.
+func (t *Table) ColumnNames() []string {
+	names := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// FindCatalog returns the catalog with the given name.
+func FindCatalog(catalogs []Catalog, name string) (*Catalog, bool) {
+	for i := range catalogs {
+		if catalogs[i].Name == name {
+			return &catalogs[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindTable looks up catalog.schema.table, returning utils.ErrNotFound
+// naming the first missing level.
+func FindTable(catalogs []Catalog, catalogName, schemaName, tableName string) (*Table, error) {
+	catalog, ok := FindCatalog(catalogs, catalogName)
+	if !ok {
+		return nil, fmt.Errorf("%w: catalog %q", utils.ErrNotFound, catalogName)
+	}
+	schema, ok := catalog.Schema(schemaName)
+	if !ok {
+		return nil, fmt.Errorf("%w: schema %q in catalog %q", utils.ErrNotFound, schemaName, catalogName)
+	}
+	table, ok := schema.Table(tableName)
+	if !ok {
+		return nil, fmt.Errorf("%w: table %q in %s.%s", utils.ErrNotFound, tableName, catalogName, schemaName)
+	}
+	return table, nil
+}
+
+// CatalogEndpoint returns the catalog metadata URL of a data dock.
+func CatalogEndpoint(client ClientInterface, dataDockID string) string {
+	return fmt.Sprintf("%s/data-docks/%s/catalog",
+		client.GetConfig().BaseURL,
+		url.PathEscape(dataDockID),
+	)
+}
+
+// FetchCatalogs retrieves and decodes the catalog metadata of a data dock,
+// using the client's CatalogCache when it has one.
+func FetchCatalogs(ctx context.Context, client ClientInterface, dataDockID string) ([]Catalog, error) {
+	if cache := catalogCacheOf(client); cache != nil {
+		return cache.Get(ctx, client, dataDockID)
+	}
+
+	resp, err := client.Do(ctx, "GET", CatalogEndpoint(client, dataDockID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	return DecodeCatalogs(resp.Data)
+}
+
+// DecodeCatalogs decodes a {"catalogs": [...]} payload. Unlike walking the
+// raw map, it fails with utils.ErrInvalidResponse on unexpected shapes
+// instead of returning partial or empty results.
+func DecodeCatalogs(data any) ([]Catalog, error) {
+	payload, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: catalog metadata is %T, expected an object", utils.ErrInvalidResponse, data)
+	}
+	rawCatalogs, ok := payload["catalogs"]
+	if !ok {
+		return nil, fmt.Errorf("%w: catalog metadata has no \"catalogs\" field", utils.ErrInvalidResponse)
+	}
+
+	var catalogs []Catalog
+	if rawCatalogs != nil {
+		if err := utils.UnmarshalData(rawCatalogs, &catalogs); err != nil {
+			return nil, fmt.Errorf("%w: %w", utils.ErrInvalidResponse, err)
+		}
+	}
+
+	for _, catalog := range catalogs {
+		if catalog.Name == "" {
+			return nil, fmt.Errorf("%w: catalog without catalog_name", utils.ErrInvalidResponse)
+		}
+		for _, schema := range catalog.Schemas {
+			if schema.Name == "" {
+				return nil, fmt.Errorf("%w: schema without schema_name in catalog %q", utils.ErrInvalidResponse, catalog.Name)
+			}
+			for _, table := range schema.Tables {
+				if table.Name == "" {
+					return nil, fmt.Errorf("%w: table without table_name in %s.%s", utils.ErrInvalidResponse, catalog.Name, schema.Name)
+				}
+				for _, column := range table.Columns {
+					if column.Name == "" {
+						return nil, fmt.Errorf("%w: column without column_name in %s.%s.%s", utils.ErrInvalidResponse, catalog.Name, schema.Name, table.Name)
+					}
+				}
+			}
+		}
+	}
+	return catalogs, nil
+}
@@ -0,0 +1,214 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// CatalogCacheProvider is implemented by clients that keep a catalog cache.
+// Catalog lookups (FetchCatalogs) go through it when available.
+type CatalogCacheProvider interface {
+	CatalogCache() *CatalogCache
+}
+
+// CatalogCache keeps the decoded catalog metadata of each data dock in
+// memory for a TTL. Expired entries are revalidated with If-None-Match when
+// the server sent an ETag, and concurrent misses for the same data dock share
+// a single request. It is safe for concurrent use.
+type CatalogCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*catalogEntry
+	calls       map[string]*catalogCall
+	generations map[string]uint64
+}
+
+type catalogEntry struct {
+	catalogs []Catalog
+	etag     string
+	fetched  time.Time
+}
+
+// catalogFetchTimeout bounds a shared fetch, which outlives the context of
+// the caller that started it.
+const catalogFetchTimeout = 2 * time.Minute
+
+// catalogCall is an in-flight fetch that concurrent callers wait on. Its
+// generation is the data dock's generation when the fetch started.
+type catalogCall struct {
+	done       chan struct{}
+	generation uint64
+	catalogs   []Catalog
+	err        error
+}
+
+// NewCatalogCache creates a cache whose entries are fresh for ttl.
+func NewCatalogCache(ttl time.Duration) *CatalogCache {
+	return &CatalogCache{
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     map[string]*catalogEntry{},
+		calls:       map[string]*catalogCall{},
+		generations: map[string]uint64{},
+	}
+}
+
+// Get returns the catalogs of a data dock, fetching them if the cached entry
+// is missing or expired. Callers receive their own copy.
+func (c *CatalogCache) Get(ctx context.Context, client ClientInterface, dataDockID string) ([]Catalog, error) {
+	c.mu.Lock()
+	entry := c.entries[dataDockID]
+	if entry != nil && c.now().Sub(entry.fetched) < c.ttl {
+		c.mu.Unlock()
+		return cloneCatalogs(entry.catalogs), nil
+	}
+
+	// A fetch started before an invalidation may return what was just
+	// invalidated, so it is not joined
+	call, ok := c.calls[dataDockID]
+	if !ok || call.generation != c.generations[dataDockID] {
+		call = &catalogCall{done: make(chan struct{}), generation: c.generations[dataDockID]}
+		c.calls[dataDockID] = call
+		// The fetch is shared by every waiter, so the caller that started
+		// it must not be able to cancel it for the others
+		go c.runFetch(context.WithoutCancel(ctx), client, dataDockID, entry, call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		return cloneCatalogs(call.catalogs), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runFetch performs a shared fetch and hands its result to the waiters.
+func (c *CatalogCache) runFetch(ctx context.Context, client ClientInterface, dataDockID string, stale *catalogEntry, call *catalogCall) {
+	ctx, cancel := context.WithTimeout(ctx, catalogFetchTimeout)
+	defer cancel()
+	fresh, err := c.fetch(ctx, client, dataDockID, stale)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A newer fetch may have replaced this one after an invalidation
+	if c.calls[dataDockID] == call {
+		delete(c.calls, dataDockID)
+	}
+	// Do not store results that raced with an invalidation
+	if err == nil && c.generations[dataDockID] == call.generation {
+		c.entries[dataDockID] = fresh
+	}
+	if fresh != nil {
+		call.catalogs = fresh.catalogs
+	}
+	call.err = err
+	close(call.done)
+}
+
+// fetch downloads the catalogs, revalidating the stale entry if it has an ETag.
+func (c *CatalogCache) fetch(ctx context.Context, client ClientInterface, dataDockID string, stale *catalogEntry) (*catalogEntry, error) {
+	endpoint := CatalogEndpoint(client, dataDockID)
+
+	var resp *utils.Response
+	var err error
+	headerClient, canRevalidate := client.(HeaderClientInterface)
+	if stale != nil && stale.etag != "" && canRevalidate {
+		resp, err = headerClient.DoWithHeaders(ctx, "GET", endpoint, nil, http.Header{"If-None-Match": {stale.etag}})
+	} else {
+		resp, err = client.Do(ctx, "GET", endpoint, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	if resp.HTTPCode == http.StatusNotModified && stale != nil {
+		return &catalogEntry{catalogs: stale.catalogs, etag: stale.etag, fetched: c.now()}, nil
+	}
+
+	catalogs, err := DecodeCatalogs(resp.Data)
+	if err != nil {
+		return nil, err
+	}
+	entry := &catalogEntry{catalogs: catalogs, fetched: c.now()}
+	if resp.Header != nil {
+		entry.etag = resp.Header.Get("ETag")
+	}
+	return entry, nil
+}
+
+// Invalidate drops the cached catalogs of a data dock. A fetch already in
+// flight is not stored, and later lookups do not wait on it.
+func (c *CatalogCache) Invalidate(dataDockID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, dataDockID)
+	c.generations[dataDockID]++
+}
+
+// InvalidateAll drops every cached catalog.
+func (c *CatalogCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for dataDockID := range c.entries {
+		c.generations[dataDockID]++
+	}
+	for dataDockID := range c.calls {
+		c.generations[dataDockID]++
+	}
+	c.entries = map[string]*catalogEntry{}
+}
+
+// InvalidateCatalogs drops the cached catalogs of a data dock, if the client
+// has a cache.
+func InvalidateCatalogs(client ClientInterface, dataDockID string) {
+	if cache := catalogCacheOf(client); cache != nil {
+		cache.Invalidate(dataDockID)
+	}
+}
+
+// InvalidateAllCatalogs drops every cached catalog, if the client has a cache.
+func InvalidateAllCatalogs(client ClientInterface) {
+	if cache := catalogCacheOf(client); cache != nil {
+		cache.InvalidateAll()
+	}
+}
+
+func catalogCacheOf(client ClientInterface) *CatalogCache {
+	if provider, ok := client.(CatalogCacheProvider); ok {
+		return provider.CatalogCache()
+	}
+	return nil
+}
+
+// cloneCatalogs deep-copies catalogs so callers cannot modify cached data.
+func cloneCatalogs(catalogs []Catalog) []Catalog {
+	if catalogs == nil {
+		return nil
+	}
+	cloned := make([]Catalog, len(catalogs))
+	for i, catalog := range catalogs {
+		cloned[i] = Catalog{Name: catalog.Name, Schemas: make([]Schema, len(catalog.Schemas))}
+		for j, schema := range catalog.Schemas {
+			cloned[i].Schemas[j] = Schema{Name: schema.Name, Tables: make([]Table, len(schema.Tables))}
+			for k, table := range schema.Tables {
+				table.Columns = append([]Column(nil), table.Columns...)
+				cloned[i].Schemas[j].Tables[k] = table
+			}
+		}
+	}
+	return cloned
+}
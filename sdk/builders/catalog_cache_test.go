@@ -0,0 +1,248 @@
+package builders
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// cachingTestClient serves a catalog with an ETag and answers If-None-Match
+// with 304 when the ETag still matches.
+type cachingTestClient struct {
+	cache    *CatalogCache
+	etag     string
+	payload  string
+	delay    time.Duration
+	requests atomic.Int32
+	notMod   atomic.Int32
+}
+
+func (c *cachingTestClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	return c.DoWithHeaders(ctx, method, endpoint, body, nil)
+}
+
+func (c *cachingTestClient) DoWithHeaders(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error) {
+	c.requests.Add(1)
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if headers.Get("If-None-Match") == c.etag {
+		c.notMod.Add(1)
+		return &utils.Response{Status: utils.StatusOK, HTTPCode: http.StatusNotModified}, nil
+	}
+	var data any
+	if err := json.Unmarshal([]byte(c.payload), &data); err != nil {
+		return nil, err
+	}
+	return &utils.Response{
+		Status:   utils.StatusOK,
+		Data:     data,
+		HTTPCode: http.StatusOK,
+		Header:   http.Header{"Etag": {c.etag}},
+	}, nil
+}
+
+func (c *cachingTestClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func (c *cachingTestClient) CatalogCache() *CatalogCache {
+	return c.cache
+}
+
+const cacheTestPayload = `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": []}]}]}`
+
+func newCachingTestClient(ttl time.Duration) (*cachingTestClient, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewCatalogCache(ttl)
+	cache.now = func() time.Time { return now }
+	return &cachingTestClient{cache: cache, etag: `"v1"`, payload: cacheTestPayload}, &now
+}
+
+func TestCatalogCache_TTLAndRevalidation(t *testing.T) {
+	client, now := newCachingTestClient(time.Minute)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := FetchCatalogs(ctx, client, "dd"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if client.requests.Load() != 1 {
+		t.Errorf("Expected a single fetch within the TTL, got %d", client.requests.Load())
+	}
+
+	*now = now.Add(2 * time.Minute)
+	catalogs, err := FetchCatalogs(ctx, client, "dd")
+	if err != nil || len(catalogs) != 1 {
+		t.Fatalf("Unexpected result %v (%v)", catalogs, err)
+	}
+	if client.requests.Load() != 2 || client.notMod.Load() != 1 {
+		t.Errorf("Expected an If-None-Match revalidation, got %d requests, %d not modified", client.requests.Load(), client.notMod.Load())
+	}
+
+	// The revalidated entry is fresh again
+	if _, err := FetchCatalogs(ctx, client, "dd"); err != nil || client.requests.Load() != 2 {
+		t.Errorf("Expected cached result after revalidation, got %d requests (%v)", client.requests.Load(), err)
+	}
+
+	// Other data docks are cached separately
+	if _, err := FetchCatalogs(ctx, client, "other"); err != nil || client.requests.Load() != 3 {
+		t.Errorf("Expected a fetch for another data dock, got %d requests (%v)", client.requests.Load(), err)
+	}
+}
+
+func TestCatalogCache_ReturnsCopies(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	ctx := context.Background()
+
+	catalogs, _ := FetchCatalogs(ctx, client, "dd")
+	catalogs[0].Schemas[0].Name = "mutated"
+
+	catalogs, _ = FetchCatalogs(ctx, client, "dd")
+	if catalogs[0].Schemas[0].Name != "public" {
+		t.Errorf("Cached data was modified through a returned value: %+v", catalogs)
+	}
+}
+
+func TestCatalogCache_Invalidate(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	ctx := context.Background()
+
+	_, _ = FetchCatalogs(ctx, client, "dd")
+	InvalidateCatalogs(client, "dd")
+	client.etag = `"v2"`
+	_, _ = FetchCatalogs(ctx, client, "dd")
+	if client.requests.Load() != 2 || client.notMod.Load() != 0 {
+		t.Errorf("Expected a full refetch after Invalidate, got %d requests", client.requests.Load())
+	}
+
+	InvalidateAllCatalogs(client)
+	_, _ = FetchCatalogs(ctx, client, "dd")
+	if client.requests.Load() != 3 {
+		t.Errorf("Expected a refetch after InvalidateAll, got %d requests", client.requests.Load())
+	}
+}
+
+func TestCatalogCache_SingleflightCollapsesConcurrentMisses(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	client.delay = 20 * time.Millisecond
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := FetchCatalogs(context.Background(), client, "dd")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if client.requests.Load() != 1 {
+		t.Errorf("Expected concurrent misses to share one request, got %d", client.requests.Load())
+	}
+}
+
+func TestCatalogCache_CancelledCallerDoesNotFailWaiters(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	client.delay = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := FetchCatalogs(ctx, client, "dd")
+		first <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := FetchCatalogs(context.Background(), client, "dd")
+		waiter <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	if err := <-first; err != context.Canceled {
+		t.Errorf("Expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+	if err := <-waiter; err != nil {
+		t.Errorf("Expected the waiter to get the shared result, got %v", err)
+	}
+	if client.requests.Load() != 1 {
+		t.Errorf("Expected one shared request, got %d", client.requests.Load())
+	}
+}
+
+func TestCatalogCache_InvalidationDuringFetchIsNotStored(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	client.delay = 20 * time.Millisecond
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = FetchCatalogs(context.Background(), client, "dd")
+	}()
+	time.Sleep(5 * time.Millisecond)
+	client.cache.Invalidate("dd")
+	<-done
+
+	_, _ = FetchCatalogs(context.Background(), client, "dd")
+	if client.requests.Load() != 2 {
+		t.Errorf("Expected the stale in-flight result to be dropped, got %d requests", client.requests.Load())
+	}
+}
+
+func TestCatalogCache_LookupAfterInvalidationDoesNotJoinStaleFetch(t *testing.T) {
+	client, _ := newCachingTestClient(time.Minute)
+	client.delay = 20 * time.Millisecond
+
+	stale := make(chan struct{})
+	go func() {
+		defer close(stale)
+		_, _ = FetchCatalogs(context.Background(), client, "dd")
+	}()
+	time.Sleep(5 * time.Millisecond)
+	client.cache.Invalidate("dd")
+
+	fresh := make(chan error, 1)
+	go func() {
+		_, err := FetchCatalogs(context.Background(), client, "dd")
+		fresh <- err
+	}()
+	<-stale
+	if client.requests.Load() != 2 {
+		t.Fatalf("Expected the lookup after Invalidate to start its own fetch, got %d requests", client.requests.Load())
+	}
+	// The stale fetch finishing must not drop the fresh one, which is joined
+	if _, err := FetchCatalogs(context.Background(), client, "dd"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := <-fresh; err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.requests.Load() != 2 {
+		t.Errorf("Expected the fresh fetch to be joined, got %d requests", client.requests.Load())
+	}
+
+	_, _ = FetchCatalogs(context.Background(), client, "dd")
+	if client.requests.Load() != 2 {
+		t.Errorf("Expected the fresh result to be cached, got %d requests", client.requests.Load())
+	}
+}
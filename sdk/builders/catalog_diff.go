@@ -0,0 +1,286 @@
+package builders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// CatalogSnapshot is a point-in-time copy of a data dock's catalog metadata
+// that can be stored as JSON and compared with DiffCatalogs.
+type CatalogSnapshot struct {
+	DataDockID string    `json:"data_dock_id"`
+	TakenAt    time.Time `json:"taken_at"`
+	Catalogs   []Catalog `json:"catalogs"`
+}
+
+// TakeSnapshot fetches the current catalog metadata of a data dock.
+func TakeSnapshot(ctx context.Context, client ClientInterface, dataDockID string) (*CatalogSnapshot, error) {
+	catalogs, err := FetchCatalogs(ctx, client, dataDockID)
+	if err != nil {
+		return nil, err
+	}
+	return &CatalogSnapshot{DataDockID: dataDockID, TakenAt: time.Now().UTC(), Catalogs: catalogs}, nil
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *CatalogSnapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// ReadSnapshot reads a snapshot written by WriteJSON.
+func ReadSnapshot(r io.Reader) (*CatalogSnapshot, error) {
+	var snapshot CatalogSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("%w: reading catalog snapshot: %w", utils.ErrInvalidResponse, err)
+	}
+	return &snapshot, nil
+}
+
+// ChangeKind identifies the kind of a SchemaChange.
+type ChangeKind string
+
+const (
+	CatalogAdded             ChangeKind = "catalog_added"
+	CatalogRemoved           ChangeKind = "catalog_removed"
+	SchemaAdded              ChangeKind = "schema_added"
+	SchemaRemoved            ChangeKind = "schema_removed"
+	TableAdded               ChangeKind = "table_added"
+	TableRemoved             ChangeKind = "table_removed"
+	ColumnAdded              ChangeKind = "column_added"
+	ColumnRemoved            ChangeKind = "column_removed"
+	ColumnTypeChanged        ChangeKind = "column_type_changed"
+	ColumnNullabilityChanged ChangeKind = "column_nullability_changed"
+)
+
+// SchemaChange is one difference between two catalog versions.
+// Old and New hold the data type or nullability for column changes.
+type SchemaChange struct {
+	Kind    ChangeKind `json:"kind"`
+	Catalog string     `json:"catalog"`
+	Schema  string     `json:"schema,omitempty"`
+	Table   string     `json:"table,omitempty"`
+	Column  string     `json:"column,omitempty"`
+	Old     string     `json:"old,omitempty"`
+	New     string     `json:"new,omitempty"`
+}
+
+// Path returns the dotted name of the changed object.
+func (c SchemaChange) Path() string {
+	parts := []string{c.Catalog}
+	for _, part := range []string{c.Schema, c.Table, c.Column} {
+		if part == "" {
+			break
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Breaking reports whether the change can break existing readers: anything
+// removed, a column type change, or a column becoming nullable.
+func (c SchemaChange) Breaking() bool {
+	switch c.Kind {
+	case CatalogRemoved, SchemaRemoved, TableRemoved, ColumnRemoved, ColumnTypeChanged:
+		return true
+	case ColumnNullabilityChanged:
+		return c.New == "true"
+	}
+	return false
+}
+
+func (c SchemaChange) String() string {
+	switch {
+	case c.Old != "" && c.New != "":
+		return fmt.Sprintf("%s %s: %s -> %s", c.Kind, c.Path(), c.Old, c.New)
+	case c.Old != "":
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.Path(), c.Old)
+	case c.New != "":
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.Path(), c.New)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, c.Path())
+}
+
+// CatalogDiff lists the changes between two catalog versions, ordered by path.
+type CatalogDiff struct {
+	Changes []SchemaChange `json:"changes"`
+}
+
+// Empty reports whether the catalogs are identical (ignoring comments).
+func (d *CatalogDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Breaking returns the changes that can break existing readers.
+func (d *CatalogDiff) Breaking() []SchemaChange {
+	var breaking []SchemaChange
+	for _, change := range d.Changes {
+		if change.Breaking() {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// Affecting returns the changes to the given table, or only to the given
+// columns of it (including removal of the table or its parents).
+//
+//	if changes := diff.Affecting("sales", "public", "orders", "id", "total"); len(changes) > 0 {
+//	    log.Fatalf("upstream schema changed: %v", changes)
+//	}
+func (d *CatalogDiff) Affecting(catalog, schema, table string, columns ...string) []SchemaChange {
+	wanted := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		wanted[column] = true
+	}
+
+	var affecting []SchemaChange
+	for _, change := range d.Changes {
+		if change.Catalog != catalog ||
+			(change.Schema != "" && change.Schema != schema) ||
+			(change.Table != "" && change.Table != table) {
+			continue
+		}
+		if change.Column != "" && len(wanted) > 0 && !wanted[change.Column] {
+			continue
+		}
+		affecting = append(affecting, change)
+	}
+	return affecting
+}
+
+// Err returns an error wrapping utils.ErrSchemaDrift listing the breaking
+// changes, or nil if there are none.
+func (d *CatalogDiff) Err() error {
+	breaking := d.Breaking()
+	if len(breaking) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(breaking))
+	for i, change := range breaking {
+		descriptions[i] = change.String()
+	}
+	return fmt.Errorf("%w: %s", utils.ErrSchemaDrift, strings.Join(descriptions, "; "))
+}
+
+// DiffSnapshots compares two snapshots of the same data dock.
+func DiffSnapshots(before, after *CatalogSnapshot) *CatalogDiff {
+	return DiffCatalogs(before.Catalogs, after.Catalogs)
+}
+
+// DiffCatalogs computes the structural changes from before to after.
+// A removed catalog, schema or table is reported once, not per column.
+func DiffCatalogs(before, after []Catalog) *CatalogDiff {
+	diff := &CatalogDiff{Changes: []SchemaChange{}}
+	add := func(change SchemaChange) {
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	beforeCatalogs := indexByName(before, func(c Catalog) string { return c.Name })
+	afterCatalogs := indexByName(after, func(c Catalog) string { return c.Name })
+	for name := range beforeCatalogs {
+		if _, ok := afterCatalogs[name]; !ok {
+			add(SchemaChange{Kind: CatalogRemoved, Catalog: name})
+		}
+	}
+
+	for catalogName, newCatalog := range afterCatalogs {
+		oldCatalog, ok := beforeCatalogs[catalogName]
+		if !ok {
+			add(SchemaChange{Kind: CatalogAdded, Catalog: catalogName})
+			continue
+		}
+
+		oldSchemas := indexByName(oldCatalog.Schemas, func(s Schema) string { return s.Name })
+		newSchemas := indexByName(newCatalog.Schemas, func(s Schema) string { return s.Name })
+		for name := range oldSchemas {
+			if _, ok := newSchemas[name]; !ok {
+				add(SchemaChange{Kind: SchemaRemoved, Catalog: catalogName, Schema: name})
+			}
+		}
+
+		for schemaName, newSchema := range newSchemas {
+			oldSchema, ok := oldSchemas[schemaName]
+			if !ok {
+				add(SchemaChange{Kind: SchemaAdded, Catalog: catalogName, Schema: schemaName})
+				continue
+			}
+
+			oldTables := indexByName(oldSchema.Tables, func(t Table) string { return t.Name })
+			newTables := indexByName(newSchema.Tables, func(t Table) string { return t.Name })
+			for name := range oldTables {
+				if _, ok := newTables[name]; !ok {
+					add(SchemaChange{Kind: TableRemoved, Catalog: catalogName, Schema: schemaName, Table: name})
+				}
+			}
+
+			for tableName, newTable := range newTables {
+				oldTable, ok := oldTables[tableName]
+				if !ok {
+					add(SchemaChange{Kind: TableAdded, Catalog: catalogName, Schema: schemaName, Table: tableName})
+					continue
+				}
+				for _, change := range diffColumns(oldTable.Columns, newTable.Columns) {
+					change.Catalog, change.Schema, change.Table = catalogName, schemaName, tableName
+					add(change)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if pi, pj := diff.Changes[i].Path(), diff.Changes[j].Path(); pi != pj {
+			return pi < pj
+		}
+		return diff.Changes[i].Kind < diff.Changes[j].Kind
+	})
+	return diff
+}
+
+func diffColumns(before, after []Column) []SchemaChange {
+	var changes []SchemaChange
+	oldColumns := indexByName(before, func(c Column) string { return c.Name })
+	newColumns := indexByName(after, func(c Column) string { return c.Name })
+
+	for name, oldColumn := range oldColumns {
+		newColumn, ok := newColumns[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Kind: ColumnRemoved, Column: name, Old: oldColumn.DataType})
+			continue
+		}
+		if !strings.EqualFold(oldColumn.DataType, newColumn.DataType) {
+			changes = append(changes, SchemaChange{Kind: ColumnTypeChanged, Column: name, Old: oldColumn.DataType, New: newColumn.DataType})
+		}
+		if oldColumn.Nullable != newColumn.Nullable {
+			changes = append(changes, SchemaChange{
+				Kind:   ColumnNullabilityChanged,
+				Column: name,
+				Old:    strconv.FormatBool(oldColumn.Nullable),
+				New:    strconv.FormatBool(newColumn.Nullable),
+			})
+		}
+	}
+	for name, newColumn := range newColumns {
+		if _, ok := oldColumns[name]; !ok {
+			changes = append(changes, SchemaChange{Kind: ColumnAdded, Column: name, New: newColumn.DataType})
+		}
+	}
+	return changes
+}
+
+func indexByName[T any](items []T, name func(T) string) map[string]T {
+	index := make(map[string]T, len(items))
+	for _, item := range items {
+		index[name(item)] = item
+	}
+	return index
+}
@@ -0,0 +1,112 @@
+package builders
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func diffTestCatalogs(orders []Column, extraSchema bool) []Catalog {
+	schemas := []Schema{{Name: "public", Tables: []Table{
+		{Name: "orders", Columns: orders},
+		{Name: "customers", Columns: []Column{{Name: "id", DataType: "bigint"}}},
+	}}}
+	if extraSchema {
+		schemas = append(schemas, Schema{Name: "staging"})
+	}
+	return []Catalog{{Name: "sales", Schemas: schemas}}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	before := diffTestCatalogs([]Column{
+		{Name: "id", DataType: "bigint"},
+		{Name: "status", DataType: "varchar", Nullable: false},
+		{Name: "total", DataType: "decimal(10,2)", Nullable: true},
+		{Name: "legacy", DataType: "varchar", Nullable: true},
+	}, true)
+	after := diffTestCatalogs([]Column{
+		{Name: "id", DataType: "BIGINT"}, // case-only type difference is not a change
+		{Name: "status", DataType: "varchar", Nullable: true},
+		{Name: "total", DataType: "decimal(12,2)", Nullable: true},
+		{Name: "created_at", DataType: "timestamp", Nullable: true},
+	}, false)
+	after[0].Schemas[0].Tables = after[0].Schemas[0].Tables[:1] // drop customers
+	after[0].Schemas[0].Tables = append(after[0].Schemas[0].Tables, Table{Name: "invoices"})
+
+	diff := DiffCatalogs(before, after)
+
+	var got []string
+	for _, change := range diff.Changes {
+		got = append(got, change.String())
+	}
+	expected := []string{
+		"table_removed sales.public.customers",
+		"table_added sales.public.invoices",
+		"column_added sales.public.orders.created_at (timestamp)",
+		"column_removed sales.public.orders.legacy (varchar)",
+		"column_nullability_changed sales.public.orders.status: false -> true",
+		"column_type_changed sales.public.orders.total: decimal(10,2) -> decimal(12,2)",
+		"schema_removed sales.staging",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected changes:\n%s\nexpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if breaking := diff.Breaking(); len(breaking) != 5 {
+		t.Errorf("Expected 5 breaking changes, got %v", breaking)
+	}
+	if err := diff.Err(); !errors.Is(err, utils.ErrSchemaDrift) || !strings.Contains(err.Error(), "orders.legacy") {
+		t.Errorf("Expected ErrSchemaDrift, got %v", err)
+	}
+
+	if changes := diff.Affecting("sales", "public", "orders", "id", "total"); len(changes) != 1 || changes[0].Column != "total" {
+		t.Errorf("Expected only the total change to affect id/total, got %v", changes)
+	}
+	if changes := diff.Affecting("sales", "public", "customers", "id"); len(changes) != 1 || changes[0].Kind != TableRemoved {
+		t.Errorf("Expected the table removal to affect customers.id, got %v", changes)
+	}
+}
+
+func TestDiffCatalogs_NoChanges(t *testing.T) {
+	catalogs := diffTestCatalogs([]Column{{Name: "id", DataType: "bigint"}}, false)
+	diff := DiffCatalogs(catalogs, diffTestCatalogs([]Column{{Name: "id", DataType: "bigint", Comment: "new comment"}}, false))
+	if !diff.Empty() || diff.Err() != nil {
+		t.Errorf("Expected no changes, got %v", diff.Changes)
+	}
+
+	diff = DiffCatalogs(nil, catalogs)
+	if len(diff.Changes) != 1 || diff.Changes[0].Kind != CatalogAdded || diff.Err() != nil {
+		t.Errorf("Expected a single non-breaking catalog_added, got %v", diff.Changes)
+	}
+}
+
+func TestCatalogSnapshot_JSONRoundTrip(t *testing.T) {
+	snapshot := &CatalogSnapshot{
+		DataDockID: "dd",
+		TakenAt:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Catalogs:   diffTestCatalogs([]Column{{Name: "id", DataType: "bigint", Nullable: false}, {Name: "note", Nullable: true}}, true),
+	}
+
+	var buf bytes.Buffer
+	if err := snapshot.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	restored, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	if restored.DataDockID != "dd" || !restored.TakenAt.Equal(snapshot.TakenAt) {
+		t.Errorf("Unexpected snapshot header: %+v", restored)
+	}
+	if diff := DiffSnapshots(snapshot, restored); !diff.Empty() {
+		t.Errorf("Expected identical catalogs after round trip, got %v", diff.Changes)
+	}
+
+	if _, err := ReadSnapshot(strings.NewReader(`{"catalogs": "nope"}`)); !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Errorf("Expected ErrInvalidResponse, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package builders
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func decodeTestPayload(t *testing.T, payload string) any {
+	t.Helper()
+	var data any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		t.Fatalf("Invalid test payload: %v", err)
+	}
+	return data
+}
+
+func TestDecodeCatalogs(t *testing.T) {
+	data := decodeTestPayload(t, `{"catalogs": [{
+		"catalog_name": "sales",
+		"schemas": [{
+			"schema_name": "public",
+			"tables": [{
+				"table_name": "orders",
+				"comment": "One row per order",
+				"columns": [
+					{"column_name": "id", "data_type": "bigint", "is_nullable": false},
+					{"column_name": "note", "data_type": "varchar", "is_nullable": "YES", "comment": "Free text"},
+					{"column_name": "status", "data_type": "varchar", "is_nullable": "NO"},
+					{"column_name": "total", "data_type": "decimal(10,2)"}
+				]
+			}]
+		}]
+	}]}`)
+
+	catalogs, err := DecodeCatalogs(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	table, err := FindTable(catalogs, "sales", "public", "orders")
+	if err != nil {
+		t.Fatalf("Expected table, got %v", err)
+	}
+	if table.Comment != "One row per order" {
+		t.Errorf("Unexpected table comment: %q", table.Comment)
+	}
+	if !slices.Equal(table.ColumnNames(), []string{"id", "note", "status", "total"}) {
+		t.Errorf("Unexpected columns: %v", table.ColumnNames())
+	}
+
+	nullable := map[string]bool{"id": false, "note": true, "status": false, "total": true}
+	for _, column := range table.Columns {
+		if column.Nullable != nullable[column.Name] {
+			t.Errorf("Column %s: expected nullable=%v", column.Name, nullable[column.Name])
+		}
+	}
+	if note, _ := table.Column("note"); note.Comment != "Free text" || note.DataType != "varchar" {
+		t.Errorf("Unexpected column: %+v", note)
+	}
+
+	if _, err := FindTable(catalogs, "sales", "private", "orders"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for missing schema, got %v", err)
+	}
+}
+
+func TestDecodeCatalogs_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{"not an object", `[]`},
+		{"missing catalogs", `{"items": []}`},
+		{"catalogs not a list", `{"catalogs": {"catalog_name": "sales"}}`},
+		{"schemas not a list", `{"catalogs": [{"catalog_name": "sales", "schemas": "public"}]}`},
+		{"missing table name", `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [{"name": "orders"}]}]}]}`},
+		{"invalid nullability", `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [
+			{"table_name": "orders", "columns": [{"column_name": "id", "is_nullable": "maybe"}]}]}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCatalogs(decodeTestPayload(t, tt.payload))
+			if !errors.Is(err, utils.ErrInvalidResponse) {
+				t.Errorf("Expected ErrInvalidResponse, got %v", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package builders
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
@@ -12,3 +13,9 @@ type ClientInterface interface {
 	Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
 	GetConfig() utils.Configuration
 }
+
+// HeaderClientInterface is implemented by clients that can send extra request
+// headers (If-Match, Prefer, ...). Builders type-assert for it when needed.
+type HeaderClientInterface interface {
+	DoWithHeaders(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error)
+}
@@ -0,0 +1,280 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Aggregate function names understood by the openapi endpoint.
+const (
+	AggSum           = "SUM"
+	AggAvg           = "AVG"
+	AggMin           = "MIN"
+	AggMax           = "MAX"
+	AggCount         = "COUNT"
+	AggCountDistinct = "COUNT_DISTINCT"
+)
+
+// AggregateRow is a single row returned by an aggregate query.
+// Group holds the GROUP BY column values, Values holds the aggregate
+// results keyed by alias.
+type AggregateRow struct {
+	Group  map[string]any
+	Values map[string]any
+}
+
+// Float returns the aggregate result for the given alias as a float64.
+// Numeric strings (as returned for DECIMAL columns) are parsed; the boolean
+// is false when the value is absent, NULL or not numeric.
+func (r AggregateRow) Float(alias string) (float64, bool) {
+	switch v := r.Values[alias].(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Int returns the aggregate result for the given alias truncated to an int64.
+func (r AggregateRow) Int(alias string) (int64, bool) {
+	f, ok := r.Float(alias)
+	return int64(f), ok
+}
+
+// GroupString returns the GROUP BY value of the given column formatted as a string.
+func (r AggregateRow) GroupString(column string) string {
+	value, ok := r.Group[column]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Sum adds SUM(column) to the query. The result alias defaults to "sum_<column>".
+func (qb *QueryBuilder) Sum(column string) *QueryBuilder {
+	return qb.addAggregate(AggSum, column)
+}
+
+// Avg adds AVG(column) to the query. The result alias defaults to "avg_<column>".
+func (qb *QueryBuilder) Avg(column string) *QueryBuilder {
+	return qb.addAggregate(AggAvg, column)
+}
+
+// Min adds MIN(column) to the query. The result alias defaults to "min_<column>".
+func (qb *QueryBuilder) Min(column string) *QueryBuilder {
+	return qb.addAggregate(AggMin, column)
+}
+
+// Max adds MAX(column) to the query. The result alias defaults to "max_<column>".
+func (qb *QueryBuilder) Max(column string) *QueryBuilder {
+	return qb.addAggregate(AggMax, column)
+}
+
+// CountAll adds COUNT(*) to the query. The result alias defaults to "count".
+func (qb *QueryBuilder) CountAll() *QueryBuilder {
+	return qb.addAggregate(AggCount, "*")
+}
+
+// CountDistinct adds COUNT(DISTINCT column) to the query.
+// The result alias defaults to "count_distinct_<column>".
+func (qb *QueryBuilder) CountDistinct(column string) *QueryBuilder {
+	return qb.addAggregate(AggCountDistinct, column)
+}
+
+// As renames the most recently added aggregate.
+//
+//	client.Catalog("sales").Schema("public").Table("orders").
+//	    GroupBy("region").
+//	    Sum("amount").As("revenue").
+//	    Aggregate(ctx)
+func (qb *QueryBuilder) As(alias string) *QueryBuilder {
+	qb = qb.Clone()
+	if len(qb.aggregates) == 0 {
+		qb.errors = append(qb.errors, fmt.Errorf("As must follow an aggregate"))
+		return qb
+	}
+	if alias == "" {
+		qb.errors = append(qb.errors, fmt.Errorf("aggregate alias cannot be empty"))
+		return qb
+	}
+	qb.aggregates[len(qb.aggregates)-1].Alias = alias
+	return qb
+}
+
+// GroupBy adds GROUP BY columns to the query.
+// Can be called multiple times to add more columns.
+func (qb *QueryBuilder) GroupBy(columns ...string) *QueryBuilder {
+	qb = qb.Clone()
+	for _, column := range columns {
+		if column == "" {
+			qb.errors = append(qb.errors, fmt.Errorf("group by column cannot be empty"))
+			continue
+		}
+		qb.groupBy = append(qb.groupBy, column)
+	}
+	return qb
+}
+
+// Having adds a condition on an aggregate result, referenced by its alias.
+// Supported operators: =, >, <, >=, <=, !=, LIKE, IN
+func (qb *QueryBuilder) Having(alias, operator string, value interface{}) *QueryBuilder {
+	qb = qb.Clone()
+	if !validOperators[operator] {
+		qb.errors = append(qb.errors, fmt.Errorf("invalid operator '%s'", operator))
+	}
+
+	qb.having = append(qb.having, builders.Filter{
+		Column:   alias,
+		Operator: operator,
+		Value:    value,
+	})
+	return qb
+}
+
+func (qb *QueryBuilder) addAggregate(function, column string) *QueryBuilder {
+	qb = qb.Clone()
+	if column == "" {
+		qb.errors = append(qb.errors, fmt.Errorf("%s column cannot be empty", strings.ToLower(function)))
+		return qb
+	}
+
+	alias := strings.ToLower(function) + "_" + column
+	if column == "*" {
+		alias = strings.ToLower(function)
+	}
+
+	qb.aggregates = append(qb.aggregates, builders.Aggregate{
+		Function: function,
+		Column:   column,
+		Alias:    alias,
+	})
+	return qb
+}
+
+// Aggregated reports whether the query aggregates (Sum, GroupBy, ...), in
+// which case its rows are not rows of the table.
+func (qb *QueryBuilder) Aggregated() bool {
+	return len(qb.aggregates) > 0 || len(qb.groupBy) > 0
+}
+
+// validateAggregation checks GROUP BY / HAVING consistency.
+func (qb *QueryBuilder) validateAggregation() error {
+	aliases := make(map[string]bool, len(qb.aggregates))
+	for _, agg := range qb.aggregates {
+		if aliases[agg.Alias] {
+			return fmt.Errorf("%w: duplicate aggregate alias '%s'", utils.ErrInvalidRequest, agg.Alias)
+		}
+		aliases[agg.Alias] = true
+	}
+
+	for _, having := range qb.having {
+		if !aliases[having.Column] {
+			return fmt.Errorf("%w: HAVING references unknown aggregate '%s'", utils.ErrInvalidRequest, having.Column)
+		}
+	}
+
+	// With aggregates, every plain selected column must be grouped.
+	if len(qb.aggregates) > 0 && len(qb.selectCols) > 0 {
+		grouped := make(map[string]bool, len(qb.groupBy))
+		for _, column := range qb.groupBy {
+			grouped[column] = true
+		}
+		for _, column := range qb.selectCols {
+			if !grouped[column] {
+				return fmt.Errorf("%w: column '%s' must appear in GROUP BY", utils.ErrInvalidRequest, column)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Aggregate executes the aggregate query and returns one row per group.
+// Aggregates are computed server-side; only the grouped results are transferred.
+func (qb *QueryBuilder) Aggregate(ctx context.Context) ([]AggregateRow, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+	if len(qb.aggregates) == 0 {
+		return nil, fmt.Errorf("%w: at least one aggregate is required", utils.ErrInvalidRequest)
+	}
+
+	resp, err := qb.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	rawRows, ok := resp.GetDataAsSlice()
+	if !ok {
+		return nil, fmt.Errorf("unable to extract aggregate rows from response")
+	}
+
+	rows := make([]AggregateRow, 0, len(rawRows))
+	for i, raw := range rawRows {
+		rowMap, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("aggregate row %d is not an object", i)
+		}
+		row, err := qb.toAggregateRow(rowMap)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate row %d: %w", i, err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// AggregateInto executes the aggregate query and decodes the rows into target,
+// which must be a pointer to a slice of structs or maps (JSON tags are honoured).
+func (qb *QueryBuilder) AggregateInto(ctx context.Context, target any) error {
+	if err := qb.validate(); err != nil {
+		return err
+	}
+	if len(qb.aggregates) == 0 {
+		return fmt.Errorf("%w: at least one aggregate is required", utils.ErrInvalidRequest)
+	}
+
+	resp, err := qb.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if resp.Status != utils.StatusOK {
+		return fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	return utils.UnmarshalData(resp.Data, target)
+}
+
+// toAggregateRow splits a raw result row into group values and aggregate values.
+func (qb *QueryBuilder) toAggregateRow(raw map[string]any) (AggregateRow, error) {
+	row := AggregateRow{
+		Group:  make(map[string]any, len(qb.groupBy)),
+		Values: make(map[string]any, len(qb.aggregates)),
+	}
+
+	for _, column := range qb.groupBy {
+		row.Group[column] = raw[column]
+	}
+
+	for _, agg := range qb.aggregates {
+		value, ok := raw[agg.Alias]
+		if !ok {
+			return row, fmt.Errorf("missing aggregate '%s'", agg.Alias)
+		}
+		row.Values[agg.Alias] = value
+	}
+
+	return row, nil
+}
@@ -0,0 +1,163 @@
+package fluent
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func TestQueryBuilder_AggregateParams(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if query.Get("_aggregate") != "sum(amount):revenue,avg(amount):avg_amount,count_distinct(customer_id):count_distinct_customer_id,count(*):count" {
+			t.Errorf("Unexpected _aggregate parameter: %s", query.Get("_aggregate"))
+		}
+		if query.Get("_group_by") != "region,year" {
+			t.Errorf("Expected _group_by=region,year, got %s", query.Get("_group_by"))
+		}
+		if query.Get("_having.revenue[>]") != "1000" {
+			t.Errorf("Expected _having.revenue[>]=1000, got %s", query.Get("_having.revenue[>]"))
+		}
+		if query.Get("status[=]") != "paid" {
+			t.Errorf("Expected status[=]=paid, got %s", query.Get("status[=]"))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`[
+				{"region":"EU","year":2024,"revenue":"1500.50","avg_amount":75.025,"count_distinct_customer_id":12,"count":20},
+				{"region":"US","year":2024,"revenue":2200,"avg_amount":null,"count_distinct_customer_id":30,"count":41}
+			]`)),
+		}, nil
+	})
+
+	rows, err := qb.
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Where("status", "=", "paid").
+		GroupBy("region", "year").
+		Sum("amount").As("revenue").
+		Avg("amount").
+		CountDistinct("customer_id").
+		CountAll().
+		Having("revenue", ">", 1000).
+		Aggregate(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	if rows[0].GroupString("region") != "EU" {
+		t.Errorf("Expected region EU, got %s", rows[0].GroupString("region"))
+	}
+	if revenue, ok := rows[0].Float("revenue"); !ok || revenue != 1500.50 {
+		t.Errorf("Expected revenue 1500.50, got %v (ok=%v)", revenue, ok)
+	}
+	if count, ok := rows[1].Int("count"); !ok || count != 41 {
+		t.Errorf("Expected count 41, got %v (ok=%v)", count, ok)
+	}
+	if _, ok := rows[1].Float("avg_amount"); ok {
+		t.Error("Expected NULL avg_amount to report ok=false")
+	}
+}
+
+func TestQueryBuilder_AggregateInto(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`[{"region":"EU","max_amount":99.5}]`)),
+		}, nil
+	})
+
+	var rows []struct {
+		Region    string  `json:"region"`
+		MaxAmount float64 `json:"max_amount"`
+	}
+	err := qb.
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Select("region").
+		GroupBy("region").
+		Max("amount").
+		AggregateInto(context.Background(), &rows)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rows) != 1 || rows[0].Region != "EU" || rows[0].MaxAmount != 99.5 {
+		t.Errorf("Unexpected rows: %+v", rows)
+	}
+}
+
+func TestQueryBuilder_AggregateValidation(t *testing.T) {
+	newQB := func() *QueryBuilder {
+		return newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+			Catalog("cat").
+			Schema("schema").
+			Table("table")
+	}
+
+	tests := []struct {
+		name     string
+		query    *QueryBuilder
+		errorMsg string
+	}{
+		{
+			name:     "no aggregate",
+			query:    newQB().GroupBy("region"),
+			errorMsg: "at least one aggregate is required",
+		},
+		{
+			name:     "having unknown alias",
+			query:    newQB().Sum("amount").Having("total", ">", 1),
+			errorMsg: "HAVING references unknown aggregate 'total'",
+		},
+		{
+			name:     "ungrouped select column",
+			query:    newQB().Select("region", "country").GroupBy("region").Sum("amount"),
+			errorMsg: "column 'country' must appear in GROUP BY",
+		},
+		{
+			name:     "duplicate alias",
+			query:    newQB().Sum("amount").Sum("amount"),
+			errorMsg: "duplicate aggregate alias 'sum_amount'",
+		},
+		{
+			name:     "As without aggregate",
+			query:    newQB().As("total"),
+			errorMsg: "As must follow an aggregate",
+		},
+		{
+			name:     "invalid having operator",
+			query:    newQB().Sum("amount").Having("sum_amount", "??", 1),
+			errorMsg: "invalid operator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.query.Aggregate(context.Background())
+			if err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error to contain '%s', got '%s'", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,539 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// BatchMode selects how a WriteBatch is committed.
+type BatchMode int
+
+const (
+	// BatchAtomic sends all operations in one request to the data dock's
+	// _batch endpoint; the server applies all of them or none.
+	BatchAtomic BatchMode = iota
+	// BatchCompensating sends operations one by one and, if one fails,
+	// undoes the previous ones: inserted rows are deleted by key, deleted
+	// rows are re-inserted and updated rows are restored from pre-images.
+	// Concurrent writers may observe intermediate states.
+	BatchCompensating
+	// BatchAuto commits atomically and falls back to BatchCompensating if the
+	// data dock does not support the _batch endpoint.
+	BatchAuto
+)
+
+func (m BatchMode) String() string {
+	switch m {
+	case BatchAtomic:
+		return "atomic"
+	case BatchCompensating:
+		return "compensating"
+	case BatchAuto:
+		return "auto"
+	}
+	return fmt.Sprintf("BatchMode(%d)", int(m))
+}
+
+// BatchOperation is a single write recorded in a WriteBatch.
+type BatchOperation struct {
+	Op     string // "insert", "update" or "delete"
+	Schema string
+	Table  string
+	Data   interface{} // Rows for insert, patch for update
+	Key    []string    // Key columns used by BatchCompensating
+	query  *QueryBuilder
+}
+
+// OperationResult reports the outcome of one operation of a committed batch.
+type OperationResult struct {
+	Operation BatchOperation
+	Affected  int // Rows written, or -1 if the server did not report it
+	Err       error
+}
+
+// WriteBatchResult reports the outcome of WriteBatch.Commit.
+type WriteBatchResult struct {
+	Mode        BatchMode // Mode actually used (never BatchAuto)
+	Operations  []OperationResult
+	RolledBack  bool  // BatchCompensating: a failure was undone
+	RollbackErr error // BatchCompensating: compensations that failed, if any
+	Response    *utils.Response
+}
+
+// WriteBatch collects inserts, updates and deletes across tables of one
+// catalog and commits them together. Unlike QueryBuilder, a WriteBatch is
+// mutable (operations are appended in place) and not safe for concurrent use.
+//
+//	orders := client.DataDock(dd).Catalog("sales").Schema("public").Table("orders")
+//	lines := orders.Table("order_lines")
+//
+//	result, err := client.DataDock(dd).Batch().
+//	    Insert(orders, header, "id").
+//	    Insert(lines, headerLines, "order_id", "line_no").
+//	    Commit(ctx)
+type WriteBatch struct {
+	qb         *QueryBuilder
+	mode       BatchMode
+	operations []BatchOperation
+	errors     []error
+}
+
+// Batch starts a WriteBatch on the builder's data dock (and catalog, if set).
+func (qb *QueryBuilder) Batch() *WriteBatch {
+	base := qb.Clone()
+	base.strict = false // operations are checked by their own builders
+	return &WriteBatch{qb: base}
+}
+
+// Mode sets how the batch is committed (default BatchAtomic).
+func (b *WriteBatch) Mode(mode BatchMode) *WriteBatch {
+	b.mode = mode
+	return b
+}
+
+// Insert adds rows (a struct, map or slice) to the table of the given builder.
+// Key lists the columns identifying a row; BatchCompensating needs it to
+// delete the inserted rows on rollback.
+func (b *WriteBatch) Insert(table *QueryBuilder, rows interface{}, key ...string) *WriteBatch {
+	return b.add("insert", table, rows, key)
+}
+
+// Update applies patch to the rows matching the builder's filters.
+// BatchCompensating needs the key columns to restore the previous rows.
+func (b *WriteBatch) Update(table *QueryBuilder, patch interface{}, key ...string) *WriteBatch {
+	return b.add("update", table, patch, key)
+}
+
+// Delete removes the rows matching the builder's filters.
+func (b *WriteBatch) Delete(table *QueryBuilder) *WriteBatch {
+	return b.add("delete", table, nil, nil)
+}
+
+// Operations returns the recorded operations.
+func (b *WriteBatch) Operations() []BatchOperation {
+	return append([]BatchOperation(nil), b.operations...)
+}
+
+func (b *WriteBatch) add(op string, table *QueryBuilder, data interface{}, key []string) *WriteBatch {
+	if err := b.check(op, table); err != nil {
+		b.errors = append(b.errors, fmt.Errorf("operation %d (%s): %w", len(b.operations), op, err))
+		return b
+	}
+	if b.qb.catalogName == "" {
+		b.qb = b.qb.Catalog(table.catalogName)
+	}
+	b.operations = append(b.operations, BatchOperation{
+		Op:     op,
+		Schema: table.schemaName,
+		Table:  table.tableName,
+		Data:   data,
+		Key:    append([]string(nil), key...),
+		query:  table.Clone(),
+	})
+	return b
+}
+
+// check validates an operation's target against the batch.
+func (b *WriteBatch) check(op string, table *QueryBuilder) error {
+	if table == nil {
+		return fmt.Errorf("%w: table builder is nil", utils.ErrInvalidRequest)
+	}
+	if err := table.validate(); err != nil {
+		return err
+	}
+	if table.dataDockID != b.qb.dataDockID {
+		return fmt.Errorf("%w: table is in data dock %q, batch is on %q", utils.ErrInvalidRequest, table.dataDockID, b.qb.dataDockID)
+	}
+	if b.qb.catalogName != "" && table.catalogName != b.qb.catalogName {
+		return fmt.Errorf("%w: table is in catalog %q, batch is on %q", utils.ErrInvalidRequest, table.catalogName, b.qb.catalogName)
+	}
+	if op != "insert" {
+		return table.validateFiltered(strings.ToUpper(op))
+	}
+	return nil
+}
+
+func (b *WriteBatch) validate() error {
+	if len(b.errors) > 0 {
+		var errMsgs []string
+		for _, err := range b.errors {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		return fmt.Errorf("write batch validation failed: %s", strings.Join(errMsgs, "; "))
+	}
+	if len(b.operations) == 0 {
+		return fmt.Errorf("%w: batch has no operations", utils.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// batchRequest is the body sent to the _batch endpoint.
+type batchRequest struct {
+	Operations []batchRequestOperation `json:"operations"`
+}
+
+type batchRequestOperation struct {
+	Op      string      `json:"op"`
+	Schema  string      `json:"schema"`
+	Table   string      `json:"table"`
+	Filters url.Values  `json:"filters,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Build returns the plan of the atomic request, without sending it.
+func (b *WriteBatch) Build() (*QueryPlan, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	body := batchRequest{Operations: make([]batchRequestOperation, 0, len(b.operations))}
+	for _, op := range b.operations {
+		reqOp := batchRequestOperation{Op: op.Op, Schema: op.Schema, Table: op.Table, Data: op.Data}
+		if op.Op != "insert" {
+			reqOp.Filters = op.query.buildParams()
+		}
+		body.Operations = append(body.Operations, reqOp)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/openapi/%s/_batch",
+		strings.TrimRight(b.qb.client.GetConfig().BaseURL, "/"),
+		url.PathEscape(b.qb.dataDockID),
+		url.PathEscape(b.qb.catalogName),
+	)
+	return newQueryPlan("POST", endpoint, nil, utils.JsonMarshal(body), nil), nil
+}
+
+// Commit sends the batch using the configured mode.
+//
+// In atomic mode a failure leaves the data unchanged. In compensating mode
+// the returned error wraps the failing operation's error and, if undoing the
+// previous operations also failed, WriteBatchResult.RollbackErr.
+func (b *WriteBatch) Commit(ctx context.Context) (*WriteBatchResult, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	switch b.mode {
+	case BatchCompensating:
+		return b.commitCompensating(ctx)
+	case BatchAuto:
+		result, err := b.commitAtomic(ctx)
+		if err != nil && b.batchUnsupported(ctx, result, err) {
+			return b.commitCompensating(ctx)
+		}
+		return result, err
+	default:
+		return b.commitAtomic(ctx)
+	}
+}
+
+// batchUnsupported reports whether the server rejected the _batch endpoint
+// itself. A 404 only counts when every table of the batch exists in the
+// catalog: a missing table is reported rather than retried operation by
+// operation.
+func (b *WriteBatch) batchUnsupported(ctx context.Context, result *WriteBatchResult, err error) bool {
+	if errors.Is(err, utils.ErrNotFound) {
+		return b.tablesExist(ctx)
+	}
+	if result == nil || result.Response == nil {
+		return false
+	}
+	code := result.Response.HTTPCode
+	return code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented
+}
+
+// tablesExist reports whether the catalog lists every table of the batch.
+func (b *WriteBatch) tablesExist(ctx context.Context) bool {
+	catalogs, err := builders.FetchCatalogs(ctx, b.qb.client, b.qb.dataDockID)
+	if err != nil {
+		return false
+	}
+	for _, op := range b.operations {
+		if _, err := builders.FindTable(catalogs, b.qb.catalogName, op.Schema, op.Table); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *WriteBatch) commitAtomic(ctx context.Context) (*WriteBatchResult, error) {
+	plan, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.qb.execute(ctx, plan)
+	result := &WriteBatchResult{Mode: BatchAtomic, Response: resp}
+	if err == nil && resp.Status != utils.StatusOK {
+		err = fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	if err != nil {
+		return result, fmt.Errorf("atomic batch failed: %w", err)
+	}
+
+	// Expected response: {"results": [{"affected_rows": n}, ...]} in operation order
+	var affected []any
+	if data, ok := resp.Data.(map[string]any); ok {
+		affected, _ = data["results"].([]any)
+	}
+	for i, op := range b.operations {
+		opResult := OperationResult{Operation: op, Affected: -1}
+		if i < len(affected) {
+			if m, ok := affected[i].(map[string]any); ok {
+				if n, ok := m["affected_rows"].(float64); ok {
+					opResult.Affected = int(n)
+				}
+			}
+		}
+		result.Operations = append(result.Operations, opResult)
+	}
+	return result, nil
+}
+
+// compensation undoes one applied operation.
+type compensation struct {
+	describe string
+	undo     func(ctx context.Context) error
+}
+
+func (b *WriteBatch) commitCompensating(ctx context.Context) (*WriteBatchResult, error) {
+	for i, op := range b.operations {
+		if op.Op != "delete" && len(op.Key) == 0 {
+			return nil, fmt.Errorf("%w: operation %d (%s %s.%s) needs key columns for compensating mode",
+				utils.ErrInvalidRequest, i, op.Op, op.Schema, op.Table)
+		}
+	}
+
+	result := &WriteBatchResult{Mode: BatchCompensating}
+	var applied []compensation
+
+	for i, op := range b.operations {
+		undo, affected, err := b.apply(ctx, op)
+		result.Operations = append(result.Operations, OperationResult{Operation: op, Affected: affected, Err: err})
+		if err == nil {
+			applied = append(applied, undo)
+			continue
+		}
+
+		opErr := fmt.Errorf("batch operation %d (%s %s.%s) failed: %w", i, op.Op, op.Schema, op.Table, err)
+		result.RollbackErr = rollback(context.WithoutCancel(ctx), applied)
+		result.RolledBack = true
+		if result.RollbackErr != nil {
+			return result, errors.Join(opErr, fmt.Errorf("rollback failed: %w", result.RollbackErr))
+		}
+		return result, opErr
+	}
+	return result, nil
+}
+
+// rollback runs the compensations in reverse order, collecting failures.
+func rollback(ctx context.Context, applied []compensation) error {
+	var errs []error
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := applied[i].undo(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", applied[i].describe, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apply executes one operation and returns how to undo it.
+func (b *WriteBatch) apply(ctx context.Context, op BatchOperation) (compensation, int, error) {
+	table := op.query
+	base := table.unfiltered()
+	name := op.Schema + "." + op.Table
+
+	switch op.Op {
+	case "insert":
+		rows, err := rowsOf(op.Data)
+		if err != nil {
+			return compensation{}, 0, err
+		}
+		undo := compensation{
+			describe: fmt.Sprintf("delete %d rows inserted into %s", len(rows), name),
+			undo: func(ctx context.Context) error {
+				return deleteByKey(ctx, base, op.Key, rows)
+			},
+		}
+		resp, err := table.Post(ctx, op.Data)
+		if err == nil && resp.Status != utils.StatusOK {
+			err = fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+		}
+		return undo, len(rows), err
+
+	case "update":
+		before, err := preImage(ctx, table, op.Key)
+		if err != nil {
+			return compensation{}, 0, fmt.Errorf("reading rows before update: %w", err)
+		}
+		undo := compensation{
+			describe: fmt.Sprintf("restore %d rows updated in %s", len(before), name),
+			undo: func(ctx context.Context) error {
+				return restoreByKey(ctx, base, op.Key, before)
+			},
+		}
+		written, err := table.Update(ctx, op.Data)
+		if err != nil {
+			return undo, 0, err
+		}
+		return undo, written.Affected, nil
+
+	case "delete":
+		before, err := preImage(ctx, table, nil)
+		if err != nil {
+			return compensation{}, 0, fmt.Errorf("reading rows before delete: %w", err)
+		}
+		undo := compensation{
+			describe: fmt.Sprintf("re-insert %d rows deleted from %s", len(before), name),
+			undo: func(ctx context.Context) error {
+				if len(before) == 0 {
+					return nil
+				}
+				_, err := base.Post(ctx, before)
+				return err
+			},
+		}
+		resp, err := table.Delete(ctx)
+		if err == nil && resp.Status != utils.StatusOK {
+			err = fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+		}
+		return undo, len(before), err
+	}
+
+	return compensation{}, 0, fmt.Errorf("%w: unknown batch operation %q", utils.ErrInvalidRequest, op.Op)
+}
+
+// unfiltered returns the builder's table with no filters or query modifiers.
+func (qb *QueryBuilder) unfiltered() *QueryBuilder {
+	base := NewQueryBuilder(qb.client).DataDock(qb.dataDockID).Catalog(qb.catalogName).Schema(qb.schemaName).Table(qb.tableName)
+	base.dryRun = qb.dryRun
+	return base
+}
+
+// preImage reads the full rows matching the builder's filters, page by
+// page and ordered by the key columns, if any. It fails when the rows read
+// do not add up to the server's count, or when a key value cannot be used
+// as an exact filter (see exactKey), so that a rollback is never silently
+// incomplete.
+func preImage(ctx context.Context, qb *QueryBuilder, key []string) ([]map[string]any, error) {
+	query := qb.unfiltered()
+	query.filters = append(query.filters, qb.filters...)
+	for _, column := range key {
+		query = query.OrderBy(column, "ASC")
+	}
+
+	var rows []map[string]any
+	for row, err := range query.Rows(ctx, 0) {
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("confirming the row count: %w", err)
+	}
+	if count != len(rows) {
+		return nil, fmt.Errorf("%w: read %d rows but %d match the filters", utils.ErrInvalidResponse, len(rows), count)
+	}
+
+	for _, row := range rows {
+		for _, column := range key {
+			value, err := exactKey(row[column])
+			if err != nil {
+				return nil, fmt.Errorf("key column %q: %w", column, err)
+			}
+			row[column] = value
+		}
+	}
+	return rows, nil
+}
+
+// maxExactFloat is the largest integer below which every float64 integer
+// is exact (2^53).
+const maxExactFloat = 1 << 53
+
+// exactKey returns a key value read from a response in a form that filters
+// on exactly that value. Responses decode numbers as float64, which Where
+// would format in exponent notation (1.234567e+06), so integral numbers are
+// turned into json.Number; fractional numbers and integers beyond 2^53,
+// which may already have lost precision, are rejected.
+func exactKey(value any) (any, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return value, nil
+	}
+	if number != math.Trunc(number) || math.Abs(number) > maxExactFloat {
+		return nil, fmt.Errorf("%w: %v cannot be matched exactly", utils.ErrInvalidResponse, number)
+	}
+	return json.Number(strconv.FormatFloat(number, 'f', -1, 64)), nil
+}
+
+// deleteByKey deletes each row identified by its key columns.
+func deleteByKey(ctx context.Context, base *QueryBuilder, key []string, rows []map[string]any) error {
+	for _, row := range rows {
+		query, err := whereKey(base, key, row)
+		if err != nil {
+			return err
+		}
+		if _, err := query.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreByKey replaces each row identified by its key columns with its previous value.
+func restoreByKey(ctx context.Context, base *QueryBuilder, key []string, rows []map[string]any) error {
+	for _, row := range rows {
+		query, err := whereKey(base, key, row)
+		if err != nil {
+			return err
+		}
+		if _, err := query.Replace(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func whereKey(base *QueryBuilder, key []string, row map[string]any) (*QueryBuilder, error) {
+	query := base
+	for _, column := range key {
+		value, ok := row[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: row has no key column %q", utils.ErrInvalidRequest, column)
+		}
+		query = query.Where(column, "=", value)
+	}
+	return query, nil
+}
+
+// rowsOf converts a struct, map or slice of them into row maps, keeping
+// numbers exact so they can be used as filter values.
+func rowsOf(data interface{}) ([]map[string]any, error) {
+	raw := bytes.TrimSpace(utils.JsonMarshal(data))
+	if len(raw) > 0 && raw[0] != '[' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var rows []map[string]any
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: insert rows must be objects: %w", utils.ErrInvalidRequest, err)
+	}
+	return rows, nil
+}
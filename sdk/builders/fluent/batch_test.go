@@ -0,0 +1,285 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// batchTestServer answers requests with a per-"METHOD path" status and body
+// and records "METHOD path?query" for every request.
+type batchTestServer struct {
+	requests  []string
+	responses map[string]string // "METHOD path" -> body
+	statuses  map[string]int    // "METHOD path" -> status
+	counts    map[string]int    // "METHOD path" -> count, default the rows of the body
+}
+
+func (s *batchTestServer) handle(req *http.Request) (*http.Response, error) {
+	route := req.Method + " " + req.URL.Path
+	call := route
+	if req.URL.RawQuery != "" {
+		call += "?" + req.URL.RawQuery
+	}
+	s.requests = append(s.requests, call)
+
+	status, ok := s.statuses[route]
+	if !ok {
+		status = http.StatusOK
+	}
+	body, ok := s.responses[route]
+	if !ok {
+		body = `{}`
+	}
+	if req.URL.Query().Get("count") == "exact" {
+		// Count the rows the route would return
+		var rows []any
+		_ = json.Unmarshal([]byte(body), &rows)
+		count, ok := s.counts[route]
+		if !ok {
+			count = len(rows)
+		}
+		body = fmt.Sprintf(`{"count": %d}`, count)
+	}
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func newBatchTestServer() (*batchTestServer, *QueryBuilder) {
+	server := &batchTestServer{responses: map[string]string{}, statuses: map[string]int{}, counts: map[string]int{}}
+	dataDock := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, server.handle)
+	return server, dataDock
+}
+
+const (
+	ordersPath = "/test-datadock/openapi/sales/public/orders"
+	linesPath  = "/test-datadock/openapi/sales/public/order_lines"
+	batchPath  = "/test-datadock/openapi/sales/_batch"
+)
+
+func TestWriteBatch_Atomic(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.responses["POST "+batchPath] = `{"results": [{"affected_rows": 1}, {"affected_rows": 2}, {"affected_rows": 5}]}`
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+	lines := orders.Table("order_lines")
+
+	batch := dataDock.Batch().
+		Insert(orders, map[string]any{"id": 1}).
+		Insert(lines, []map[string]any{{"order_id": 1, "line_no": 1}, {"order_id": 1, "line_no": 2}}).
+		Delete(lines.Where("order_id", "=", 0))
+
+	plan, err := batch.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var body struct {
+		Operations []struct {
+			Op      string              `json:"op"`
+			Table   string              `json:"table"`
+			Filters map[string][]string `json:"filters"`
+		} `json:"operations"`
+	}
+	if err := json.Unmarshal(plan.Body(), &body); err != nil {
+		t.Fatalf("Invalid batch body: %v", err)
+	}
+	if len(body.Operations) != 3 || body.Operations[1].Table != "order_lines" || body.Operations[2].Op != "delete" {
+		t.Errorf("Unexpected operations: %+v", body.Operations)
+	}
+	if got := body.Operations[2].Filters["order_id[=]"]; len(got) != 1 || got[0] != "0" {
+		t.Errorf("Expected delete filters, got %v", body.Operations[2].Filters)
+	}
+
+	result, err := batch.Commit(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(server.requests) != 1 || server.requests[0] != "POST "+batchPath {
+		t.Errorf("Expected a single batch request, got %v", server.requests)
+	}
+	if result.Mode != BatchAtomic || result.Operations[2].Affected != 5 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestWriteBatch_Validation(t *testing.T) {
+	_, dataDock := newBatchTestServer()
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+	ctx := context.Background()
+
+	if _, err := dataDock.Batch().Commit(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected error for empty batch, got %v", err)
+	}
+
+	other := dataDock.Catalog("finance").Schema("public").Table("invoices")
+	if _, err := dataDock.Batch().Insert(orders, map[string]any{"id": 1}).Insert(other, map[string]any{"id": 1}).Commit(ctx); err == nil ||
+		!strings.Contains(err.Error(), `catalog "finance"`) {
+		t.Errorf("Expected cross-catalog error, got %v", err)
+	}
+
+	if _, err := dataDock.Batch().Delete(orders).Commit(ctx); err == nil || !strings.Contains(err.Error(), "AllRows") {
+		t.Errorf("Expected unfiltered delete error, got %v", err)
+	}
+
+	_, err := dataDock.Batch().Mode(BatchCompensating).Insert(orders, map[string]any{"id": 1}).Commit(ctx)
+	if !errors.Is(err, utils.ErrInvalidRequest) || !strings.Contains(err.Error(), "key columns") {
+		t.Errorf("Expected missing key error, got %v", err)
+	}
+}
+
+func TestWriteBatch_CompensatingRollback(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.responses["GET "+ordersPath] = `[{"id": 7, "status": "open"}]`
+	server.statuses["POST "+linesPath] = http.StatusBadRequest
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+	lines := orders.Table("order_lines")
+
+	result, err := dataDock.Batch().Mode(BatchCompensating).
+		Insert(orders, map[string]any{"id": 12345678901234, "status": "new"}, "id").
+		Update(orders.Where("id", "=", 7), map[string]any{"status": "closed"}, "id").
+		Insert(lines, map[string]any{"order_id": 12345678901234, "line_no": 1}, "order_id", "line_no").
+		Commit(context.Background())
+
+	if !errors.Is(err, utils.ErrAPIError) {
+		t.Fatalf("Expected the failing insert error, got %v", err)
+	}
+	if !result.RolledBack || result.RollbackErr != nil {
+		t.Errorf("Expected a clean rollback, got %+v", result)
+	}
+
+	expected := []string{
+		"POST " + ordersPath,
+		"GET " + ordersPath + "?_limit=1000&id%5B%3D%5D=7&order=id.asc",
+		"GET " + ordersPath + "?_limit=0&count=exact&id%5B%3D%5D=7&order=id.asc",
+		"PATCH " + ordersPath + "?id%5B%3D%5D=7",
+		"POST " + linesPath,
+		// rollback, in reverse order
+		"PUT " + ordersPath + "?id%5B%3D%5D=7",
+		"DELETE " + ordersPath + "?id%5B%3D%5D=12345678901234",
+	}
+	if strings.Join(server.requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected requests:\n%s\nexpected:\n%s", strings.Join(server.requests, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestWriteBatch_CompensatingRollbackExactKeys(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.responses["GET "+ordersPath] = `[{"id": 1234567, "status": "open"}]`
+	server.statuses["POST "+linesPath] = http.StatusBadRequest
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+
+	result, err := dataDock.Batch().Mode(BatchCompensating).
+		Update(orders.Where("status", "=", "open"), map[string]any{"status": "closed"}, "id").
+		Insert(orders.Table("order_lines"), map[string]any{"order_id": 1234567}, "order_id").
+		Commit(context.Background())
+	if !errors.Is(err, utils.ErrAPIError) || !result.RolledBack || result.RollbackErr != nil {
+		t.Fatalf("Expected a clean rollback, got %+v (%v)", result, err)
+	}
+	restore := "PUT " + ordersPath + "?id%5B%3D%5D=1234567"
+	if last := server.requests[len(server.requests)-1]; last != restore {
+		t.Errorf("Expected the row restored by its exact key %s, got %s", restore, last)
+	}
+
+	// Keys beyond 2^53 may already have lost precision
+	server.requests = nil
+	server.responses["GET "+ordersPath] = `[{"id": 18014398509481985, "status": "open"}]`
+	_, err = dataDock.Batch().Mode(BatchCompensating).
+		Update(orders.Where("status", "=", "open"), map[string]any{"status": "closed"}, "id").
+		Commit(context.Background())
+	if !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Fatalf("Expected an inexact key error, got %v", err)
+	}
+	for _, request := range server.requests {
+		if strings.HasPrefix(request, "PATCH") {
+			t.Errorf("Expected no update without exact keys, got %s", request)
+		}
+	}
+}
+
+func TestWriteBatch_CompensatingRestoresDeletedRows(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.responses["GET "+linesPath] = `[{"order_id": 7, "line_no": 1}]`
+	server.statuses["POST "+ordersPath] = http.StatusBadRequest
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+	lines := orders.Table("order_lines")
+
+	var requestBodies []string
+	dataDock.client.(*mockClient).handler = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		requestBodies = append(requestBodies, req.Method+" "+string(body))
+		return server.handle(req)
+	}
+
+	_, err := dataDock.Batch().Mode(BatchCompensating).
+		Delete(lines.Where("order_id", "=", 7)).
+		Insert(orders, map[string]any{"id": 8}, "id").
+		Commit(context.Background())
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	last := requestBodies[len(requestBodies)-1]
+	if last != `POST [{"line_no":1,"order_id":7}]` {
+		t.Errorf("Expected deleted rows to be re-inserted, got %s", last)
+	}
+}
+
+func TestWriteBatch_CompensatingRefusesTruncatedPreImage(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.responses["GET "+ordersPath] = `[{"id": 7, "status": "open"}]`
+	server.counts["GET "+ordersPath] = 1500
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+
+	_, err := dataDock.Batch().Mode(BatchCompensating).
+		Update(orders.Where("status", "=", "open"), map[string]any{"status": "closed"}, "id").
+		Commit(context.Background())
+	if !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Fatalf("Expected an incomplete pre-image error, got %v", err)
+	}
+	for _, request := range server.requests {
+		if strings.HasPrefix(request, "PATCH") {
+			t.Errorf("Expected no update without a complete pre-image, got %s", request)
+		}
+	}
+}
+
+func TestWriteBatch_AutoFallsBackWhenUnsupported(t *testing.T) {
+	server, dataDock := newBatchTestServer()
+	server.statuses["POST "+batchPath] = http.StatusNotFound
+	server.responses["GET /data-docks/test-datadock/catalog"] = describeTestCatalog
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+
+	result, err := dataDock.Batch().Mode(BatchAuto).
+		Insert(orders, map[string]any{"id": 1}, "id").
+		Commit(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Mode != BatchCompensating {
+		t.Errorf("Expected fallback to compensating mode, got %s", result.Mode)
+	}
+	want := "POST " + batchPath + ",GET /data-docks/test-datadock/catalog,POST " + ordersPath
+	if strings.Join(server.requests, ",") != want {
+		t.Errorf("Unexpected requests: %v", server.requests)
+	}
+
+	// A 404 for a table missing from the catalog is not a missing _batch route
+	server.requests = nil
+	result, err = dataDock.Batch().Mode(BatchAuto).
+		Insert(orders.Table("missing"), map[string]any{"id": 1}, "id").
+		Commit(context.Background())
+	if !errors.Is(err, utils.ErrNotFound) || result.Mode != BatchAtomic {
+		t.Errorf("Expected the atomic ErrNotFound, got %v", err)
+	}
+	if strings.Join(server.requests, ",") != "POST "+batchPath+",GET /data-docks/test-datadock/catalog" {
+		t.Errorf("Unexpected requests: %v", server.requests)
+	}
+}
@@ -0,0 +1,134 @@
+package fluent
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func TestQueryBuilder_BranchingIsIndependent(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Select("id").
+		Where("region", "=", "EU").
+		RawParams(url.Values{"custom": {"base"}})
+
+	paid := base.Select("total").Where("status", "=", "paid").RawParams(url.Values{"custom": {"paid"}})
+	open := base.Where("status", "=", "open").Limit(5)
+
+	basePlan, err := base.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	paidPlan, err := paid.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	openPlan, err := open.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if basePlan.Params().Get("status[=]") != "" || basePlan.Params().Get("_limit") != "" {
+		t.Errorf("Base query was mutated by its branches: %s", basePlan.URL())
+	}
+	if basePlan.Params().Get("select") != "id" || len(basePlan.Params()["custom"]) != 1 {
+		t.Errorf("Base query was mutated by its branches: %s", basePlan.URL())
+	}
+	if paidPlan.Params().Get("select") != "id,total" || paidPlan.Params().Get("status[=]") != "paid" {
+		t.Errorf("Unexpected paid branch: %s", paidPlan.URL())
+	}
+	if openPlan.Params().Get("select") != "id" || openPlan.Params().Get("status[=]") != "open" {
+		t.Errorf("Unexpected open branch: %s", openPlan.URL())
+	}
+}
+
+func TestQueryBuilder_ErrorsDoNotLeakAcrossBranches(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Table("orders")
+
+	_ = base.Limit(-1)
+
+	if _, err := base.Build(); err != nil {
+		t.Errorf("Invalid branch should not poison the base query, got %v", err)
+	}
+}
+
+func TestQueryBuilder_Clone(t *testing.T) {
+	original := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		GroupBy("region").
+		Sum("amount")
+
+	clone := original.Clone()
+	clone.aggregates[0].Alias = "changed"
+	clone.groupBy[0] = "changed"
+
+	if original.aggregates[0].Alias != "sum_amount" || original.groupBy[0] != "region" {
+		t.Error("Clone should not share slices with the original")
+	}
+}
+
+// Run with -race to verify that concurrent branching does not share state.
+func TestQueryBuilder_ConcurrentBranching(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Select("id").
+		Where("region", "=", "EU")
+
+	const workers = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			value := fmt.Sprintf("customer-%d", i)
+			plan, err := base.
+				Select("total").
+				Where("customer", "=", value).
+				OrderBy("created_at", "DESC").
+				Limit(i + 1).
+				Build()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			params := plan.Params()
+			if params.Get("customer[=]") != value || len(params["customer[=]"]) != 1 {
+				errs <- fmt.Errorf("worker %d saw foreign filters: %s", i, plan.URL())
+			}
+			if params.Get("select") != "id,total" {
+				errs <- fmt.Errorf("worker %d saw foreign select: %s", i, plan.URL())
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	plan, err := base.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if plan.Params().Get("customer[=]") != "" || plan.Params().Get("order") != "" {
+		t.Errorf("Base query was mutated concurrently: %s", plan.URL())
+	}
+}
@@ -0,0 +1,114 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Describe returns the table's metadata (columns, data types, nullability
+// and comments) from the data dock catalog. Unknown catalogs, schemas or
+// tables return utils.ErrNotFound.
+//
+//	table, err := client.Catalog("sales").Schema("public").Table("orders").Describe(ctx)
+//	for _, column := range table.Columns {
+//	    fmt.Println(column.Name, column.DataType, column.Nullable)
+//	}
+func (qb *QueryBuilder) Describe(ctx context.Context) (*builders.Table, error) {
+	if err := qb.validatePath(); err != nil {
+		return nil, err
+	}
+	catalogs, err := builders.FetchCatalogs(ctx, qb.client, qb.dataDockID)
+	if err != nil {
+		return nil, err
+	}
+	return builders.FindTable(catalogs, qb.catalogName, qb.schemaName, qb.tableName)
+}
+
+// Strict makes terminal operations check the selected, filtered, ordered,
+// grouped and aggregated columns against the table schema (see Describe)
+// before sending the request. Unknown columns fail with
+// utils.ErrInvalidRequest and nothing is sent. The table is described once
+// per terminal operation, including paged ones such as Rows and Export; use
+// StrictSchema to reuse the metadata across queries.
+func (qb *QueryBuilder) Strict() *QueryBuilder {
+	qb = qb.Clone()
+	qb.strict = true
+	return qb
+}
+
+// StrictSchema is like Strict but checks columns against the given table
+// metadata instead of fetching it.
+func (qb *QueryBuilder) StrictSchema(table *builders.Table) *QueryBuilder {
+	qb = qb.Clone()
+	qb.strict = true
+	qb.strictTable = table
+	return qb
+}
+
+// checkColumns verifies in strict mode that every referenced column exists.
+func (qb *QueryBuilder) checkColumns(ctx context.Context) error {
+	qb, err := qb.resolveStrict(ctx)
+	if err != nil {
+		return err
+	}
+	return qb.checkTableColumns(qb.strictTable)
+}
+
+// resolveStrict returns the builder with its strict-mode table metadata
+// resolved, so that terminals sending several requests (Rows, Export,
+// InsertBatch, ...) describe the table once rather than per request.
+func (qb *QueryBuilder) resolveStrict(ctx context.Context) (*QueryBuilder, error) {
+	if !qb.strict || qb.strictTable != nil {
+		return qb, nil
+	}
+	table, err := qb.Describe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("strict mode: %w", err)
+	}
+	qb = qb.Clone()
+	qb.strictTable = table
+	return qb, nil
+}
+
+// checkTableColumns verifies that every referenced column exists in table.
+func (qb *QueryBuilder) checkTableColumns(table *builders.Table) error {
+	known := make(map[string]bool, len(table.Columns))
+	for _, column := range table.Columns {
+		known[column.Name] = true
+	}
+
+	var unknown []string
+	check := func(clause, column string) {
+		if !known[column] {
+			unknown = append(unknown, fmt.Sprintf("%s %q", clause, column))
+		}
+	}
+	for _, column := range qb.selectCols {
+		check("select", column)
+	}
+	for _, filter := range qb.filters {
+		check("where", filter.Column)
+	}
+	for _, order := range qb.orderBy {
+		check("order by", order.Column)
+	}
+	for _, column := range qb.groupBy {
+		check("group by", column)
+	}
+	for _, aggregate := range qb.aggregates {
+		if aggregate.Column != "*" {
+			check(strings.ToLower(aggregate.Function), aggregate.Column)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown columns in %s.%s.%s: %s (available: %s)",
+			utils.ErrInvalidRequest, qb.catalogName, qb.schemaName, qb.tableName,
+			strings.Join(unknown, ", "), strings.Join(table.ColumnNames(), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const describeTestCatalog = `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [
+	{"table_name": "orders", "columns": [
+		{"column_name": "id", "data_type": "bigint", "is_nullable": false},
+		{"column_name": "status", "data_type": "varchar", "is_nullable": true},
+		{"column_name": "total", "data_type": "decimal(10,2)", "is_nullable": true}
+	]}
+]}]}]}`
+
+// newDescribeTestQueryBuilder serves the catalog above and records data requests.
+func newDescribeTestQueryBuilder(dataRequests *[]string) *QueryBuilder {
+	return newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		body := `[]`
+		if req.URL.Path == "/data-docks/test-datadock/catalog" {
+			body = describeTestCatalog
+		} else {
+			*dataRequests = append(*dataRequests, req.Method+" "+req.URL.Path)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func TestQueryBuilder_Describe(t *testing.T) {
+	var requests []string
+	qb := newDescribeTestQueryBuilder(&requests).Catalog("sales").Schema("public")
+
+	table, err := qb.Table("orders").Describe(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if table.Name != "orders" || len(table.Columns) != 3 {
+		t.Fatalf("Unexpected table: %+v", table)
+	}
+	if id, _ := table.Column("id"); id.DataType != "bigint" || id.Nullable {
+		t.Errorf("Unexpected id column: %+v", id)
+	}
+
+	if _, err := qb.Table("missing").Describe(context.Background()); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := qb.Describe(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected error for missing table name, got %v", err)
+	}
+}
+
+func TestQueryBuilder_Strict(t *testing.T) {
+	var requests []string
+	orders := newDescribeTestQueryBuilder(&requests).Catalog("sales").Schema("public").Table("orders").Strict()
+	ctx := context.Background()
+
+	if _, err := orders.Select("id", "total").Where("status", "=", "paid").OrderBy("id", "desc").Get(ctx); err != nil {
+		t.Errorf("Expected valid query to pass, got %v", err)
+	}
+
+	_, err := orders.Select("id", "totl").Where("state", "=", "paid").OrderBy("id", "desc").Get(ctx)
+	if !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+	}
+	for _, want := range []string{`select "totl"`, `where "state"`, "available: id, status, total"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error: %v", want, err)
+		}
+	}
+
+	if _, err := orders.GroupBy("status").Sum("amount").Aggregate(ctx); err == nil || !strings.Contains(err.Error(), `sum "amount"`) {
+		t.Errorf("Expected unknown aggregate column error, got %v", err)
+	}
+
+	if len(requests) != 1 {
+		t.Errorf("Only the valid query should be sent, got %v", requests)
+	}
+}
+
+func TestQueryBuilder_StrictDescribesOncePerTerminal(t *testing.T) {
+	var catalogFetches, pages int
+	orders := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		body := `[]`
+		if req.URL.Path == "/data-docks/test-datadock/catalog" {
+			catalogFetches++
+			body = describeTestCatalog
+		} else {
+			pages++
+			if req.URL.Query().Get("_offset") != "2" {
+				body = `[{"id": 1}]`
+			}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("sales").Schema("public").Table("orders").Strict().Select("id")
+
+	n := 0
+	for _, err := range orders.Rows(context.Background(), 1) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		n++
+	}
+	if n != 2 || pages != 3 {
+		t.Fatalf("Expected 2 rows over 3 pages, got %d rows over %d pages", n, pages)
+	}
+	if catalogFetches != 1 {
+		t.Errorf("Expected the catalog to be fetched once, got %d", catalogFetches)
+	}
+}
+
+func TestQueryBuilder_StrictSchema(t *testing.T) {
+	var requests []string
+	schema := &builders.Table{Name: "orders", Columns: []builders.Column{{Name: "id"}}}
+	orders := newDescribeTestQueryBuilder(&requests).Catalog("sales").Schema("public").Table("orders").StrictSchema(schema)
+
+	if _, err := orders.Where("id", "=", 1).Get(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := orders.Where("status", "=", "paid").Get(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the valid query to be sent, got %v", requests)
+	}
+
+	if _, err := orders.Select("total").Build(); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected Build to check columns, got %v", err)
+	}
+	if _, err := orders.Select("id").Build(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// ExportFormat is a file format written by Export. ExportCSV and
+// ExportNDJSON are built in; package export provides Parquet.
+type ExportFormat interface {
+	// ContentType returns the MIME type of the format, used for S3 uploads.
+	ContentType() string
+	// NewRowWriter returns a writer of columns to w. table is the queried
+	// table's catalog metadata; the built-in formats get nil when columns
+	// were selected, since they do not need it.
+	NewRowWriter(w io.Writer, table *builders.Table, columns []string, opts ExportOptions) (RowWriter, error)
+}
+
+// textFormat is a built-in ExportFormat, which only needs column names.
+type textFormat string
+
+const (
+	// ExportCSV writes a header line followed by one line per row.
+	ExportCSV textFormat = "csv"
+	// ExportNDJSON writes one JSON object per line.
+	ExportNDJSON textFormat = "ndjson"
+)
+
+func (f textFormat) ContentType() string {
+	if f == ExportNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+func (f textFormat) NewRowWriter(w io.Writer, _ *builders.Table, columns []string, _ ExportOptions) (RowWriter, error) {
+	if f == ExportNDJSON {
+		return NewNDJSONWriter(w, columns), nil
+	}
+	return NewCSVWriter(w, columns), nil
+}
+
+// ExportOptions configures Export and ExportToS3.
+type ExportOptions struct {
+	// Format of the output, ExportCSV if nil.
+	Format ExportFormat
+	// PageSize is the number of rows fetched per request and, for formats
+	// that buffer rows such as Parquet, the number of rows per row group
+	// (DefaultRowsPageSize if zero).
+	PageSize int
+}
+
+// RowWriter writes result rows to an output in a given format.
+type RowWriter interface {
+	// Write writes one row; missing columns are written as nulls.
+	Write(row map[string]any) error
+	// Close flushes buffered rows and finishes the output. It does not
+	// close the underlying io.Writer.
+	Close() error
+}
+
+// WriteRows writes every row of rows to w, then closes w. It returns the
+// number of rows written and stops at the first error.
+func WriteRows(rows iter.Seq2[map[string]any, error], w RowWriter) (int, error) {
+	n := 0
+	for row, err := range rows {
+		if err == nil {
+			err = w.Write(row)
+		}
+		if err != nil {
+			_ = w.Close()
+			return n, err
+		}
+		n++
+	}
+	return n, w.Close()
+}
+
+// Export streams the rows of the query to w (see Rows) and returns the
+// number of rows written. Columns are written in the order given to
+// Select, or in the table's catalog order when nothing is selected; the
+// catalog is also passed to formats other than the built-in ones, e.g. for
+// the Parquet schema. Aggregations are not supported.
+//
+//	file, _ := os.Create("orders.csv")
+//	defer file.Close()
+//	n, err := table.Select("id", "total").OrderBy("id", "ASC").
+//	    Export(ctx, file, fluent.ExportOptions{Format: fluent.ExportCSV})
+func (qb *QueryBuilder) Export(ctx context.Context, w io.Writer, opts ExportOptions) (int, error) {
+	if err := qb.validate(); err != nil {
+		return 0, err
+	}
+	if qb.Aggregated() {
+		return 0, fmt.Errorf("%w: aggregate queries cannot be exported", utils.ErrInvalidRequest)
+	}
+	qb, err := qb.resolveStrict(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := qb.rowWriter(ctx, w, opts)
+	if err != nil {
+		return 0, err
+	}
+	return WriteRows(qb.Rows(ctx, opts.PageSize), writer)
+}
+
+// ExportToS3 is like Export but uploads the output to the bucket and key
+// of dest while it is written.
+//
+//	s3, err := client.S3()
+//	...
+//	dest := s3.Bucket("exports").Key("orders.ndjson")
+//	n, err := table.ExportToS3(ctx, dest, fluent.ExportOptions{Format: fluent.ExportNDJSON})
+func (qb *QueryBuilder) ExportToS3(ctx context.Context, dest *S3Builder, opts ExportOptions) (int, error) {
+	if opts.Format == nil {
+		opts.Format = ExportCSV
+	}
+
+	reader, writer := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		err := dest.Put(ctx, reader, opts.Format.ContentType())
+		// Unblock the exporter if the upload stopped reading.
+		_ = reader.CloseWithError(err)
+		uploaded <- err
+	}()
+
+	n, err := qb.Export(ctx, writer, opts)
+	_ = writer.CloseWithError(err)
+	if uploadErr := <-uploaded; err == nil {
+		err = uploadErr
+	}
+	return n, err
+}
+
+// rowWriter returns the writer of opts.Format for the query's columns.
+func (qb *QueryBuilder) rowWriter(ctx context.Context, w io.Writer, opts ExportOptions) (RowWriter, error) {
+	format := opts.Format
+	if format == nil {
+		format = ExportCSV
+	}
+
+	table := qb.strictTable
+	columns := qb.selectCols
+	if _, builtIn := format.(textFormat); table == nil && (!builtIn || len(columns) == 0) {
+		var err error
+		if table, err = qb.Describe(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(columns) == 0 {
+		columns = table.ColumnNames()
+	}
+	return format.NewRowWriter(w, table, columns, opts)
+}
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []string
+	record  []string
+}
+
+// NewCSVWriter returns a RowWriter writing columns as CSV to w, starting
+// with a header line. Nulls are written as empty fields and nested values
+// as JSON.
+func NewCSVWriter(w io.Writer, columns []string) RowWriter {
+	writer := csv.NewWriter(w)
+	// The header error, if any, is reported by the next Write or Close.
+	_ = writer.Write(columns)
+	return &csvWriter{writer: writer, columns: columns, record: make([]string, len(columns))}
+}
+
+func (c *csvWriter) Write(row map[string]any) error {
+	for i, column := range c.columns {
+		c.record[i] = csvField(row[column])
+	}
+	if err := c.writer.Write(c.record); err != nil {
+		return err
+	}
+	return c.writer.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func csvField(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64, json.Number:
+		return numberText(v)
+	}
+	return string(utils.JsonMarshal(value))
+}
+
+// numberText returns the text of a JSON number (or numeric string).
+func numberText(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+type ndjsonWriter struct {
+	w       io.Writer
+	columns []string
+	line    bytes.Buffer
+}
+
+// NewNDJSONWriter returns a RowWriter writing one JSON object per line to
+// w, with the keys in the order of columns.
+func NewNDJSONWriter(w io.Writer, columns []string) RowWriter {
+	return &ndjsonWriter{w: w, columns: columns}
+}
+
+func (n *ndjsonWriter) Write(row map[string]any) error {
+	n.line.Reset()
+	n.line.WriteByte('{')
+	for i, column := range n.columns {
+		if i > 0 {
+			n.line.WriteByte(',')
+		}
+		value, err := json.Marshal(row[column])
+		if err != nil {
+			return fmt.Errorf("%w: column %q: %w", utils.ErrInvalidResponse, column, err)
+		}
+		n.line.Write(utils.JsonMarshal(column))
+		n.line.WriteByte(':')
+		n.line.Write(value)
+	}
+	n.line.WriteString("}\n")
+	_, err := n.w.Write(n.line.Bytes())
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
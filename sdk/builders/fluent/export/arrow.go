@@ -0,0 +1,370 @@
+// Package export reads and writes query results in Apache Arrow formats:
+// Arrow record batches and Parquet files. It is separate from package
+// fluent so that programs that do not use these formats do not link the
+// Arrow libraries.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// arrowAccept asks for an Arrow IPC stream, falling back to JSON when the
+// server cannot produce one.
+const arrowAccept = utils.ArrowStreamMediaType + ", application/json;q=0.9"
+
+// ArrowRecords runs query and yields its result as Arrow record batches,
+// one per page of fluent.DefaultRowsPageSize rows (see QueryBuilder.Rows
+// for paging). It is a function rather than a QueryBuilder method so that
+// package fluent does not depend on arrow-go.
+//
+// Each request negotiates the response format with an Accept header. When
+// the server answers with an Arrow IPC stream (utils.ArrowStreamMediaType)
+// its batches are yielded as they are, without any JSON involved. JSON rows
+// are converted to a schema derived from the catalog column types (see
+// QueryBuilder.Describe) for the selected columns, or all of the table's
+// columns.
+//
+// A record is only valid during its iteration: call Retain to keep it (and
+// Release when done). Aggregations are not supported. Iteration stops at
+// the first error, which is yielded with a nil record.
+//
+//	for record, err := range export.ArrowRecords(ctx, table.Select("id", "total").OrderBy("id", "ASC")) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(record.NumRows(), record.Column(1))
+//	}
+func ArrowRecords(ctx context.Context, query *fluent.QueryBuilder) iter.Seq2[arrow.RecordBatch, error] {
+	return func(yield func(arrow.RecordBatch, error) bool) {
+		if query.Aggregated() {
+			yield(nil, fmt.Errorf("%w: aggregate queries cannot be read as Arrow records", utils.ErrInvalidRequest))
+			return
+		}
+
+		table, err := query.Describe(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		schema, err := arrowSchema(table, query.SelectedColumns())
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer builder.Release()
+
+		err = query.Pages(ctx, 0, http.Header{"Accept": {arrowAccept}}, func(resp *utils.Response) (int, bool) {
+			if stream, ok := resp.Data.([]byte); ok {
+				return yieldArrowStream(stream, yield)
+			}
+
+			rows, err := fluent.ResponseRows(resp)
+			if err != nil {
+				yield(nil, err)
+				return 0, false
+			}
+			if len(rows) == 0 {
+				return 0, true
+			}
+			for _, row := range rows {
+				if err := appendRow(builder, row); err != nil {
+					yield(nil, err)
+					return 0, false
+				}
+			}
+			record := builder.NewRecordBatch()
+			defer record.Release()
+			return len(rows), yield(record, nil)
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// yieldArrowStream yields the record batches of an Arrow IPC stream and
+// returns their number of rows and whether to go on.
+func yieldArrowStream(stream []byte, yield func(arrow.RecordBatch, error) bool) (int, bool) {
+	reader, err := ipc.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		yield(nil, fmt.Errorf("%w: arrow stream: %w", utils.ErrInvalidResponse, err))
+		return 0, false
+	}
+	defer reader.Release()
+
+	n := 0
+	for reader.Next() {
+		record := reader.RecordBatch()
+		n += int(record.NumRows())
+		if !yield(record, nil) {
+			return n, false
+		}
+	}
+	if err := reader.Err(); err != nil {
+		yield(nil, fmt.Errorf("%w: arrow stream: %w", utils.ErrInvalidResponse, err))
+		return n, false
+	}
+	return n, true
+}
+
+// catalogTypePattern splits a catalog data type such as "decimal(10,2)"
+// into its base name and parameters.
+var catalogTypePattern = regexp.MustCompile(`^\s*([a-zA-Z ]+?)\s*(?:\(([^)]*)\))?\s*$`)
+
+// arrowType maps a catalog data type (Trino/SQL naming) to an Arrow type.
+// Types without an Arrow equivalent (JSON, arrays, maps, rows, intervals,
+// ...) are kept as strings, nested values encoded as JSON.
+func arrowType(dataType string) arrow.DataType {
+	base, params := strings.ToLower(strings.TrimSpace(dataType)), ""
+	if match := catalogTypePattern.FindStringSubmatch(base); match != nil {
+		base, params = match[1], match[2]
+	}
+
+	switch base {
+	case "bigint", "int8", "long":
+		return arrow.PrimitiveTypes.Int64
+	case "integer", "int", "int4":
+		return arrow.PrimitiveTypes.Int32
+	case "smallint", "int2":
+		return arrow.PrimitiveTypes.Int16
+	case "tinyint":
+		return arrow.PrimitiveTypes.Int8
+	case "real", "float4":
+		return arrow.PrimitiveTypes.Float32
+	case "double", "double precision", "float", "float8":
+		return arrow.PrimitiveTypes.Float64
+	case "decimal", "numeric":
+		precision, scale, ok := decimalParams(params)
+		if !ok {
+			return arrow.BinaryTypes.String
+		}
+		return &arrow.Decimal128Type{Precision: precision, Scale: scale}
+	case "boolean", "bool":
+		return arrow.FixedWidthTypes.Boolean
+	case "date":
+		return arrow.FixedWidthTypes.Date32
+	case "timestamp", "timestamp without time zone":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case "timestamp with time zone":
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	}
+	// Parameterized timestamps, e.g. "timestamp(3) with time zone".
+	if strings.HasPrefix(base, "timestamp(") {
+		if strings.HasSuffix(base, "with time zone") {
+			return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+		}
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	}
+	return arrow.BinaryTypes.String
+}
+
+// decimalParams parses the "precision,scale" of a decimal type. Decimals
+// without an explicit precision, or wider than Decimal128, are not mapped.
+func decimalParams(params string) (int32, int32, bool) {
+	precisionText, scaleText, _ := strings.Cut(params, ",")
+	precision, err := strconv.Atoi(strings.TrimSpace(precisionText))
+	if err != nil || precision < 1 || precision > 38 {
+		return 0, 0, false
+	}
+	scale := 0
+	if scaleText != "" {
+		if scale, err = strconv.Atoi(strings.TrimSpace(scaleText)); err != nil || scale < 0 || scale > precision {
+			return 0, 0, false
+		}
+	}
+	return int32(precision), int32(scale), true
+}
+
+// arrowSchema derives the Arrow schema of columns (all of the table's if
+// empty) from the table's catalog metadata, in the order given.
+func arrowSchema(table *builders.Table, columns []string) (*arrow.Schema, error) {
+	if len(columns) == 0 {
+		columns = table.ColumnNames()
+	}
+	fields := make([]arrow.Field, 0, len(columns))
+	for _, name := range columns {
+		column, ok := table.Column(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: column %q does not exist in table %q", utils.ErrInvalidRequest, name, table.Name)
+		}
+		fields = append(fields, arrow.Field{Name: column.Name, Type: arrowType(column.DataType), Nullable: column.Nullable})
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// appendRow appends a result row to the fields of builder, converting the
+// JSON values to the fields' types.
+func appendRow(builder *array.RecordBuilder, row map[string]any) error {
+	for i, field := range builder.Schema().Fields() {
+		if err := appendValue(builder.Field(i), field, row[field.Name]); err != nil {
+			return fmt.Errorf("%w: column %q: %w", utils.ErrInvalidResponse, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// timestampLayouts are the timestamp formats accepted for date and
+// timestamp columns, the first matching one wins.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 Z07:00",
+	"2006-01-02 15:04:05.999999999 MST",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+func parseTimestamp(text string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date or timestamp %q", text)
+}
+
+func appendValue(builder array.Builder, field arrow.Field, value any) error {
+	if value == nil {
+		if !field.Nullable {
+			return fmt.Errorf("null in a non-nullable column")
+		}
+		builder.AppendNull()
+		return nil
+	}
+
+	switch b := builder.(type) {
+	case *array.StringBuilder:
+		if text, ok := value.(string); ok {
+			b.Append(text)
+		} else {
+			b.Append(string(utils.JsonMarshal(value)))
+		}
+		return nil
+	case *array.BooleanBuilder:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected a boolean, got %T", value)
+		}
+		b.Append(v)
+		return nil
+	case *array.Decimal128Builder:
+		decimalType := field.Type.(*arrow.Decimal128Type)
+		num, err := decimal128.FromString(numberText(value), decimalType.Precision, decimalType.Scale)
+		if err != nil {
+			return err
+		}
+		b.Append(num)
+		return nil
+	case *array.Date32Builder, *array.TimestampBuilder:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a date or timestamp string, got %T", value)
+		}
+		t, err := parseTimestamp(text)
+		if err != nil {
+			return err
+		}
+		if date, ok := b.(*array.Date32Builder); ok {
+			date.Append(arrow.Date32FromTime(t))
+			return nil
+		}
+		timestamp, err := arrow.TimestampFromTime(t, arrow.Microsecond)
+		if err != nil {
+			return err
+		}
+		b.(*array.TimestampBuilder).Append(timestamp)
+		return nil
+	}
+
+	text := numberText(value)
+	switch b := builder.(type) {
+	case *array.Float64Builder:
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %v", value)
+		}
+		b.Append(v)
+	case *array.Float32Builder:
+		v, err := strconv.ParseFloat(text, 32)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %v", value)
+		}
+		b.Append(float32(v))
+	case *array.Int64Builder:
+		v, err := parseInt(text, 64)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.Int32Builder:
+		v, err := parseInt(text, 32)
+		if err != nil {
+			return err
+		}
+		b.Append(int32(v))
+	case *array.Int16Builder:
+		v, err := parseInt(text, 16)
+		if err != nil {
+			return err
+		}
+		b.Append(int16(v))
+	case *array.Int8Builder:
+		v, err := parseInt(text, 8)
+		if err != nil {
+			return err
+		}
+		b.Append(int8(v))
+	default:
+		return fmt.Errorf("unsupported Arrow type %s", field.Type)
+	}
+	return nil
+}
+
+// parseInt parses an integer that fits in bits, also written as an
+// integral float ("3.0", "1e3").
+func parseInt(text string, bits int) (int64, error) {
+	if v, err := strconv.ParseInt(text, 10, bits); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	limit := math.Ldexp(1, bits-1)
+	if err != nil || f != math.Trunc(f) || f < -limit || f >= limit {
+		return 0, fmt.Errorf("expected an integer of %d bits, got %s", bits, text)
+	}
+	return int64(f), nil
+}
+
+// numberText returns the text of a JSON number (or numeric string).
+func numberText(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
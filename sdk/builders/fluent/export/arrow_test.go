@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func TestArrowType(t *testing.T) {
+	tests := map[string]string{
+		"bigint":                      "int64",
+		"INTEGER":                     "int32",
+		"double precision":            "float64",
+		"decimal(10, 2)":              "decimal(10, 2)",
+		"decimal(40,2)":               "utf8",
+		"date":                        "date32",
+		"timestamp(3)":                "timestamp[us]",
+		"timestamp(6) with time zone": "timestamp[us, tz=UTC]",
+		"varchar(255)":                "utf8",
+		"array(integer)":              "utf8",
+	}
+	for dataType, want := range tests {
+		if got := arrowType(dataType).String(); got != want {
+			t.Errorf("arrowType(%q) = %s, expected %s", dataType, got, want)
+		}
+	}
+}
+
+func TestArrowRecords(t *testing.T) {
+	var requests []string
+	qb := newTestQuery(&requests)
+
+	var records int
+	for record, err := range ArrowRecords(context.Background(), qb.Select("id", "total")) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		records++
+		if record.NumRows() != 3 || record.Schema().Field(1).Type.ID() != arrow.DECIMAL128 {
+			t.Fatalf("Unexpected record: %s with %d rows", record.Schema(), record.NumRows())
+		}
+		if ids := record.Column(0).(*array.Int64); ids.Value(2) != 3 {
+			t.Errorf("Expected id 3, got %d", ids.Value(2))
+		}
+		if !record.Column(1).IsNull(2) {
+			t.Errorf("Expected a null total")
+		}
+	}
+	if records != 1 || len(requests) != 1 {
+		t.Errorf("Expected one record from one request, got %d from %v", records, requests)
+	}
+
+	for _, err := range ArrowRecords(context.Background(), qb.CountAll()) {
+		if !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("Expected ErrInvalidRequest for an aggregate, got %v", err)
+		}
+	}
+}
+
+func TestArrowRecords_IPC(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	record := builder.NewRecordBatch()
+	defer record.Release()
+
+	var stream bytes.Buffer
+	writer := ipc.NewWriter(&stream, ipc.WithSchema(schema))
+	if err := writer.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var accept string
+	qb := fluent.NewQueryBuilder(&testClient{handler: func(req *http.Request) (string, []byte) {
+		accept = req.Header.Get("Accept")
+		return utils.ArrowStreamMediaType, stream.Bytes()
+	}})
+
+	var ids []int64
+	for record, err := range ArrowRecords(context.Background(), qb.Catalog("sales").Schema("public").Table("orders").Select("id")) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, record.Column(0).(*array.Int64).Int64Values()...)
+	}
+	if len(ids) != 2 || ids[1] != 2 {
+		t.Errorf("Expected ids 1 and 2 from the stream, got %v", ids)
+	}
+	if !strings.HasPrefix(accept, utils.ArrowStreamMediaType) {
+		t.Errorf("Expected an Arrow Accept header, got %q", accept)
+	}
+}
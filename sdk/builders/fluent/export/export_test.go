@@ -0,0 +1,128 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const testCatalog = `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [
+	{"table_name": "orders", "columns": [
+		{"column_name": "id", "data_type": "bigint", "is_nullable": false},
+		{"column_name": "status", "data_type": "varchar", "is_nullable": true},
+		{"column_name": "total", "data_type": "decimal(10,2)", "is_nullable": true}
+	]}
+]}]}]}`
+
+// testRows are the rows of the orders table of testCatalog.
+var testRows = []string{
+	`{"id": 1, "status": "paid", "total": 10.5}`,
+	`{"id": 2, "status": null, "total": 20}`,
+	`{"id": 3, "status": "said \"hi\"", "total": null}`,
+}
+
+// testClient serves testCatalog and answers data requests with handler,
+// whose body is decoded like the SDK client does.
+type testClient struct {
+	handler func(req *http.Request) (contentType string, body []byte)
+}
+
+func (c *testClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	return c.DoWithHeaders(ctx, method, endpoint, body, nil)
+}
+
+func (c *testClient) DoWithHeaders(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers.Clone()
+
+	header, data := http.Header{}, []byte(testCatalog)
+	if req.URL.Path != "/data-docks/test-datadock/catalog" {
+		var contentType string
+		contentType, data = c.handler(req)
+		header.Set("Content-Type", contentType)
+	}
+	resp := &utils.Response{Status: utils.StatusOK, HTTPCode: http.StatusOK, Header: header}
+	if utils.IsArrowStream(header) {
+		resp.Data = data
+	} else if err := json.Unmarshal(data, &resp.Data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *testClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com", Token: "test-token", DataDockID: "test-datadock"}
+}
+
+// newTestQuery serves pages of testRows for the orders table, recording the
+// data requests.
+func newTestQuery(requests *[]string) *fluent.QueryBuilder {
+	return fluent.NewQueryBuilder(&testClient{handler: func(req *http.Request) (string, []byte) {
+		*requests = append(*requests, req.URL.RawQuery)
+		query := req.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("_offset"))
+		limit, _ := strconv.Atoi(query.Get("_limit"))
+		end := min(offset+limit, len(testRows))
+		return "application/json", []byte("[" + strings.Join(testRows[min(offset, end):end], ",") + "]")
+	}}).Catalog("sales").Schema("public").Table("orders")
+}
+
+func TestParquet(t *testing.T) {
+	var requests []string
+	var out bytes.Buffer
+	n, err := newTestQuery(&requests).Export(context.Background(), &out, fluent.ExportOptions{Format: Parquet, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 3 || len(requests) != 2 {
+		t.Errorf("Expected 3 rows in 2 requests, got %d in %v", n, requests)
+	}
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(out.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("Expected a valid Parquet file, got %v", err)
+	}
+	defer table.Release()
+
+	schema := table.Schema()
+	if schema.NumFields() != 3 || table.NumRows() != 3 {
+		t.Fatalf("Unexpected table: %s with %d rows", schema, table.NumRows())
+	}
+	if id := schema.Field(0); id.Type.ID() != arrow.INT64 || id.Nullable {
+		t.Errorf("Expected a non-nullable int64 id, got %s", id)
+	}
+	total, ok := schema.Field(2).Type.(*arrow.Decimal128Type)
+	if !ok || total.Precision != 10 || total.Scale != 2 {
+		t.Fatalf("Expected total as decimal(10,2), got %s", schema.Field(2).Type)
+	}
+
+	totals := table.Column(2).Data().Chunk(0).(*array.Decimal128)
+	if got := totals.Value(0); got != decimal128.FromI64(1050) {
+		t.Errorf("Expected 10.50, got %s", got.ToString(2))
+	}
+	statuses := table.Column(1).Data().Chunk(0).(*array.String)
+	if statuses.Value(0) != "paid" || !statuses.IsNull(1) {
+		t.Errorf("Unexpected statuses: %s", statuses)
+	}
+
+	if _, err := newTestQuery(&requests).Select("missing").Export(context.Background(), &out, fluent.ExportOptions{Format: Parquet}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an unknown column, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// exportTestRows are the rows of the orders table of describeTestCatalog.
+var exportTestRows = []string{
+	`{"id": 1, "status": "paid", "total": 10.5}`,
+	`{"id": 2, "status": null, "total": 20}`,
+	`{"id": 3, "status": "said \"hi\"", "total": null}`,
+}
+
+// newExportTestQueryBuilder serves describeTestCatalog and pages of
+// exportTestRows, recording the data requests.
+func newExportTestQueryBuilder(requests *[]string) *QueryBuilder {
+	return newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		body := describeTestCatalog
+		if req.URL.Path != "/data-docks/test-datadock/catalog" {
+			*requests = append(*requests, req.URL.RawQuery)
+			query := req.URL.Query()
+			offset, _ := strconv.Atoi(query.Get("_offset"))
+			limit, _ := strconv.Atoi(query.Get("_limit"))
+			end := min(offset+limit, len(exportTestRows))
+			body = "[" + strings.Join(exportTestRows[min(offset, end):end], ",") + "]"
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("sales").Schema("public").Table("orders")
+}
+
+func TestQueryBuilder_Rows(t *testing.T) {
+	var requests []string
+	qb := newExportTestQueryBuilder(&requests)
+
+	var ids []any
+	for row, err := range qb.Rows(context.Background(), 2) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, row["id"])
+	}
+	if len(ids) != 3 || ids[2] != float64(3) {
+		t.Errorf("Expected ids 1 to 3, got %v", ids)
+	}
+	want := []string{"_limit=2", "_limit=2&_offset=2"}
+	if strings.Join(requests, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+
+	// Offset is where iteration starts and Limit caps it.
+	requests = nil
+	n := 0
+	for _, err := range qb.Offset(1).Limit(1).Rows(context.Background(), 2) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		n++
+	}
+	if n != 1 || len(requests) != 1 || requests[0] != "_limit=1&_offset=1" {
+		t.Errorf("Expected one row in one request, got %d rows and %v", n, requests)
+	}
+}
+
+func TestQueryBuilder_Export(t *testing.T) {
+	var requests []string
+	qb := newExportTestQueryBuilder(&requests)
+
+	tests := []struct {
+		name string
+		qb   *QueryBuilder
+		opts ExportOptions
+		want string
+	}{
+		{
+			name: "csv with selected columns",
+			qb:   qb.Select("total", "id"),
+			opts: ExportOptions{PageSize: 2},
+			want: "total,id\n10.5,1\n20,2\n,3\n",
+		},
+		{
+			name: "csv with catalog columns",
+			qb:   qb,
+			opts: ExportOptions{Format: ExportCSV},
+			want: "id,status,total\n1,paid,10.5\n2,,20\n3,\"said \"\"hi\"\"\",\n",
+		},
+		{
+			name: "ndjson",
+			qb:   qb.Select("status", "id"),
+			opts: ExportOptions{Format: ExportNDJSON},
+			want: `{"status":"paid","id":1}` + "\n" + `{"status":null,"id":2}` + "\n" + `{"status":"said \"hi\"","id":3}` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := test.qb.Export(context.Background(), &out, test.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if n != 3 || out.String() != test.want {
+				t.Errorf("Expected 3 rows:\n%s\ngot %d:\n%s", test.want, n, out.String())
+			}
+		})
+	}
+
+	if _, err := qb.Sum("total").Export(context.Background(), io.Discard, ExportOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an aggregate, got %v", err)
+	}
+}
+
+func TestQueryBuilder_ExportToS3(t *testing.T) {
+	var uploads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploads = append(uploads, r.Method+" "+r.URL.Path+" "+r.Header.Get("Content-Type")+"\n"+string(body))
+	}))
+	defer server.Close()
+
+	dest, err := NewS3Builder(&mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "key",
+		MinIOSecretKey: "secret",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []string
+	n, err := newExportTestQueryBuilder(&requests).Select("id").
+		ExportToS3(context.Background(), dest.Bucket("exports").Key("orders.ndjson"), ExportOptions{Format: ExportNDJSON})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := "PUT /exports/orders.ndjson application/x-ndjson\n{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+	if n != 3 || len(uploads) != 1 || uploads[0] != want {
+		t.Errorf("Expected 3 rows uploaded as\n%s\ngot %d rows and %q", want, n, uploads)
+	}
+}
+
+func TestS3Builder_PutMultipart(t *testing.T) {
+	var requests []string
+	var completed string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		query := r.URL.Query()
+		switch {
+		case r.Method == "POST" && query.Has("uploads"):
+			requests = append(requests, "create "+r.Header.Get("Content-Type"))
+			_, _ = io.WriteString(w, `<InitiateMultipartUploadResult><Bucket>exports</Bucket><Key>big.csv</Key><UploadId>u-1</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == "PUT" && query.Has("partNumber"):
+			requests = append(requests, "part "+query.Get("partNumber")+" of "+query.Get("uploadId"))
+			w.Header().Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+		case r.Method == "POST" && query.Has("uploadId"):
+			requests = append(requests, "complete "+query.Get("uploadId"))
+			completed = string(body)
+			_, _ = io.WriteString(w, `<CompleteMultipartUploadResult><Bucket>exports</Bucket><Key>big.csv</Key></CompleteMultipartUploadResult>`)
+		default:
+			requests = append(requests, r.Method+" "+r.URL.String())
+		}
+	}))
+	defer server.Close()
+
+	dest, err := NewS3Builder(&mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "key",
+		MinIOSecretKey: "secret",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := strings.NewReader(strings.Repeat("x", putPartSize+10))
+	if err := dest.Bucket("exports").Key("big.csv").Put(context.Background(), body, "text/csv"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []string{"create text/csv", "part 1 of u-1", "part 2 of u-1", "complete u-1"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+	if !strings.Contains(completed, "<PartNumber>1</PartNumber>") || !strings.Contains(completed, "<PartNumber>2</PartNumber>") {
+		t.Errorf("Expected both parts in the completion, got %s", completed)
+	}
+}
@@ -0,0 +1,237 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sort"
+	"sync"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Default values for BatchOptions.
+const (
+	DefaultBatchSize        = 1000
+	DefaultBatchConcurrency = 1
+)
+
+// BatchOptions controls how InsertBatch chunks and sends rows.
+type BatchOptions struct {
+	Size        int  // Rows per request (default DefaultBatchSize)
+	Concurrency int  // Maximum requests in flight (default DefaultBatchConcurrency)
+	StopOnError bool // Stop sending new batches after the first failure (batches in flight complete)
+}
+
+// BatchResult reports the outcome of a single batch.
+type BatchResult struct {
+	Index    int // Batch number, starting at 0
+	FirstRow int // Index of the first row of the batch in the input
+	Rows     int // Number of rows in the batch
+	Response *utils.Response
+	Err      error
+}
+
+// RowIndices returns the input indices of the rows in this batch.
+func (r BatchResult) RowIndices() []int {
+	indices := make([]int, r.Rows)
+	for i := range indices {
+		indices[i] = r.FirstRow + i
+	}
+	return indices
+}
+
+// BatchReport summarizes an InsertBatch run.
+type BatchReport struct {
+	Batches      []BatchResult // Sent batches, ordered by Index
+	TotalRows    int           // Rows read from the input
+	InsertedRows int           // Rows in successful batches
+	FailedRows   []int         // Input indices of rows in failed batches
+	SkippedRows  int           // Rows read but never sent because of StopOnError or cancellation
+}
+
+// Failed returns the batches that failed.
+func (r *BatchReport) Failed() []BatchResult {
+	var failed []BatchResult
+	for _, batch := range r.Batches {
+		if batch.Err != nil {
+			failed = append(failed, batch)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing the failed batches, or nil if all succeeded.
+func (r *BatchReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d batches failed (%d rows), first error: %w",
+		len(failed), len(r.Batches), len(r.FailedRows), failed[0].Err)
+}
+
+// SliceSeq adapts a slice of rows for InsertBatch.
+func SliceSeq[T any](rows []T) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for _, row := range rows {
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// AnySeq adapts a typed iterator (e.g. slices.Values or a file reader) for InsertBatch.
+func AnySeq[T any](rows iter.Seq[T]) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for row := range rows {
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// ChanSeq adapts a channel of rows for InsertBatch. The sequence ends when the
+// channel is closed.
+func ChanSeq[T any](rows <-chan T) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for row := range rows {
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// InsertBatch reads rows from the sequence, chunks them into batches of
+// opts.Size and POSTs up to opts.Concurrency batches in parallel.
+// Rows are consumed lazily, so large inputs can be streamed from disk.
+//
+// The returned report lists every sent batch and the input indices of failed
+// rows. The error is non-nil if the query is invalid, the context is
+// cancelled, or at least one batch failed (see BatchReport.Err).
+//
+//	report, err := client.Catalog("sales").Schema("public").Table("orders").
+//	    InsertBatch(ctx, fluent.SliceSeq(orders), fluent.BatchOptions{Size: 500, Concurrency: 4})
+func (qb *QueryBuilder) InsertBatch(ctx context.Context, rows iter.Seq[any], opts BatchOptions) (*BatchReport, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+	qb, err := qb.resolveStrict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Size < 0 || opts.Concurrency < 0 {
+		return nil, fmt.Errorf("%w: batch size and concurrency cannot be negative", utils.ErrInvalidRequest)
+	}
+	if opts.Size == 0 {
+		opts.Size = DefaultBatchSize
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultBatchConcurrency
+	}
+
+	report := &BatchReport{}
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, opts.Concurrency)
+		// stop is closed after the first failure with StopOnError. It only
+		// stops dispatching: batches in flight complete on ctx, since the
+		// server may commit them anyway and reporting them as failed would
+		// make retries insert duplicates.
+		stop     = make(chan struct{})
+		stopOnce sync.Once
+	)
+
+	send := func(index, firstRow int, batch []any) {
+		defer wg.Done()
+		defer func() { <-sem }()
+
+		resp, err := qb.Post(ctx, batch)
+		if err == nil && resp != nil && resp.Status != utils.StatusOK {
+			err = fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.Batches = append(report.Batches, BatchResult{
+			Index:    index,
+			FirstRow: firstRow,
+			Rows:     len(batch),
+			Response: resp,
+			Err:      err,
+		})
+		if err != nil && opts.StopOnError {
+			stopOnce.Do(func() { close(stop) })
+		}
+	}
+
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return ctx.Err() != nil
+		}
+	}
+
+	batch := make([]any, 0, opts.Size)
+	batchIndex, firstRow := 0, 0
+	flush := func() bool {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		case <-stop:
+			return false
+		}
+		if stopped() {
+			<-sem
+			return false
+		}
+		wg.Add(1)
+		go send(batchIndex, firstRow, batch)
+		batchIndex++
+		firstRow += len(batch)
+		batch = make([]any, 0, opts.Size)
+		return true
+	}
+
+	for row := range rows {
+		report.TotalRows++
+		batch = append(batch, row)
+		if len(batch) == opts.Size && !flush() {
+			break // stop consuming the input
+		}
+	}
+	if len(batch) > 0 && !stopped() {
+		flush()
+	}
+
+	wg.Wait()
+
+	sort.Slice(report.Batches, func(i, j int) bool {
+		return report.Batches[i].Index < report.Batches[j].Index
+	})
+	sent := 0
+	for _, result := range report.Batches {
+		sent += result.Rows
+		if result.Err != nil {
+			report.FailedRows = append(report.FailedRows, result.RowIndices()...)
+		} else {
+			report.InsertedRows += result.Rows
+		}
+	}
+	report.SkippedRows = report.TotalRows - sent
+
+	if err := report.Err(); err != nil {
+		return report, err
+	}
+	if report.SkippedRows > 0 {
+		return report, fmt.Errorf("%d rows were not sent: %w", report.SkippedRows, context.Cause(ctx))
+	}
+	return report, nil
+}
@@ -0,0 +1,206 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+type testRow struct {
+	ID int `json:"id"`
+}
+
+func makeTestRows(n int) []testRow {
+	rows := make([]testRow, n)
+	for i := range rows {
+		rows[i] = testRow{ID: i}
+	}
+	return rows
+}
+
+func newBatchTestQueryBuilder(handler func(rows []testRow) int) *QueryBuilder {
+	return newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		var rows []testRow
+		if err := json.NewDecoder(req.Body).Decode(&rows); err != nil {
+			return nil, err
+		}
+		status := handler(rows)
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}, nil
+	}).Catalog("sales").Schema("public").Table("orders")
+}
+
+func TestQueryBuilder_InsertBatchChunks(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	qb := newBatchTestQueryBuilder(func(rows []testRow) int {
+		mu.Lock()
+		defer mu.Unlock()
+		sizes = append(sizes, len(rows))
+		return http.StatusCreated
+	})
+
+	report, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(25)), BatchOptions{Size: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !slices.Equal(sizes, []int{10, 10, 5}) {
+		t.Errorf("Expected batches of 10,10,5, got %v", sizes)
+	}
+	if report.TotalRows != 25 || report.InsertedRows != 25 || len(report.FailedRows) != 0 || report.SkippedRows != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if len(report.Batches) != 3 || report.Batches[2].FirstRow != 20 {
+		t.Errorf("Unexpected batches: %+v", report.Batches)
+	}
+}
+
+func TestQueryBuilder_InsertBatchPartialFailure(t *testing.T) {
+	qb := newBatchTestQueryBuilder(func(rows []testRow) int {
+		if rows[0].ID == 10 {
+			return http.StatusBadRequest
+		}
+		return http.StatusCreated
+	})
+
+	report, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(30)), BatchOptions{Size: 10, Concurrency: 3})
+	if err == nil {
+		t.Fatal("Expected error for failed batch")
+	}
+	if !errors.Is(err, utils.ErrAPIError) {
+		t.Errorf("Expected ErrAPIError, got %v", err)
+	}
+
+	if report.InsertedRows != 20 {
+		t.Errorf("Expected 20 inserted rows, got %d", report.InsertedRows)
+	}
+	if !slices.Equal(report.FailedRows, []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}) {
+		t.Errorf("Unexpected failed rows: %v", report.FailedRows)
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Index != 1 {
+		t.Errorf("Unexpected failed batches: %+v", failed)
+	}
+}
+
+func TestQueryBuilder_InsertBatchStopOnError(t *testing.T) {
+	var calls atomic.Int32
+	qb := newBatchTestQueryBuilder(func(rows []testRow) int {
+		calls.Add(1)
+		return http.StatusBadRequest
+	})
+
+	report, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(50)), BatchOptions{Size: 10, StopOnError: true})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single request before stopping, got %d", calls.Load())
+	}
+	if len(report.FailedRows) != 10 || report.InsertedRows != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if report.TotalRows != report.SkippedRows+10 {
+		t.Errorf("Read rows should be either failed or skipped: %+v", report)
+	}
+}
+
+func TestQueryBuilder_InsertBatchStopOnErrorInFlight(t *testing.T) {
+	var calls atomic.Int32
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		var rows []testRow
+		if err := json.NewDecoder(req.Body).Decode(&rows); err != nil {
+			return nil, err
+		}
+		// The first batch fails once all three batches are in flight
+		if calls.Add(1); rows[0].ID == 0 {
+			for deadline := time.Now().Add(time.Second); calls.Load() < 3 && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+			}
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader("bad row"))}, nil
+		}
+		time.Sleep(30 * time.Millisecond)
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}).Catalog("sales").Schema("public").Table("orders")
+
+	report, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(50)), BatchOptions{Size: 10, Concurrency: 3, StopOnError: true})
+	if err == nil || report == nil {
+		t.Fatalf("Expected a report and an error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected no batch sent after the failure, got %d requests", calls.Load())
+	}
+	// Batches already in flight complete instead of being aborted
+	if len(report.FailedRows) != 10 || report.InsertedRows != 20 || report.SkippedRows != report.TotalRows-30 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestQueryBuilder_InsertBatchConcurrencyAndChannel(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	qb := newBatchTestQueryBuilder(func(rows []testRow) int {
+		current := inFlight.Add(1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return http.StatusCreated
+	})
+
+	ch := make(chan testRow)
+	go func() {
+		defer close(ch)
+		for _, row := range makeTestRows(100) {
+			ch <- row
+		}
+	}()
+
+	report, err := qb.InsertBatch(context.Background(), ChanSeq(ch), BatchOptions{Size: 5, Concurrency: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.InsertedRows != 100 || len(report.Batches) != 20 {
+		t.Errorf("Unexpected report: %d rows in %d batches", report.InsertedRows, len(report.Batches))
+	}
+	if maxInFlight.Load() > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, saw %d", maxInFlight.Load())
+	}
+}
+
+func TestQueryBuilder_InsertBatchValidation(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).Catalog("sales")
+
+	if _, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(1)), BatchOptions{}); err == nil {
+		t.Error("Expected validation error for incomplete table path")
+	}
+
+	qb = qb.Schema("public").Table("orders")
+	if _, err := qb.InsertBatch(context.Background(), SliceSeq(makeTestRows(1)), BatchOptions{Size: -1}); err == nil {
+		t.Error("Expected error for negative batch size")
+	}
+}
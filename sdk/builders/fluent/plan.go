@@ -0,0 +1,200 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// QueryPlan is an immutable description of the HTTP request a QueryBuilder sends.
+// Accessors return copies, so a plan can be shared and logged safely.
+type QueryPlan struct {
+	method   string
+	endpoint string
+	params   url.Values
+	body     []byte
+	headers  http.Header
+}
+
+func newQueryPlan(method, endpoint string, params url.Values, body []byte, headers http.Header) *QueryPlan {
+	return &QueryPlan{
+		method:   method,
+		endpoint: endpoint,
+		params:   copyValues(params),
+		body:     append([]byte(nil), body...),
+		headers:  headers.Clone(),
+	}
+}
+
+// Method returns the HTTP method.
+func (p *QueryPlan) Method() string {
+	return p.method
+}
+
+// Endpoint returns the URL without query parameters.
+func (p *QueryPlan) Endpoint() string {
+	return p.endpoint
+}
+
+// URL returns the full URL including encoded query parameters.
+func (p *QueryPlan) URL() string {
+	if len(p.params) == 0 {
+		return p.endpoint
+	}
+	return p.endpoint + "?" + p.params.Encode()
+}
+
+// Params returns a copy of the query parameters.
+func (p *QueryPlan) Params() url.Values {
+	return copyValues(p.params)
+}
+
+// Body returns a copy of the JSON request body (nil for GET and DELETE).
+func (p *QueryPlan) Body() []byte {
+	if p.body == nil {
+		return nil
+	}
+	return append([]byte(nil), p.body...)
+}
+
+// Headers returns a copy of the extra request headers (If-Match, Prefer, ...).
+// The Authorization and Content-Type headers are added by the client.
+func (p *QueryPlan) Headers() http.Header {
+	return p.headers.Clone()
+}
+
+// String renders the plan as "METHOD URL", followed by the body if any.
+func (p *QueryPlan) String() string {
+	if len(p.body) == 0 {
+		return p.method + " " + p.URL()
+	}
+	return p.method + " " + p.URL() + "\n" + string(p.body)
+}
+
+// Curl renders the plan as a curl command line.
+// The token is left as a $HYPERFLUID_TOKEN shell variable so it never ends up in logs.
+func (p *QueryPlan) Curl() string {
+	parts := []string{
+		"curl",
+		"-X", p.method,
+		shellQuote(p.URL()),
+		"-H", shellQuote("Authorization: Bearer ") + `"$HYPERFLUID_TOKEN"`,
+	}
+	for _, key := range slices.Sorted(maps.Keys(p.headers)) {
+		for _, value := range p.headers[key] {
+			parts = append(parts, "-H", shellQuote(key+": "+value))
+		}
+	}
+	if len(p.body) > 0 {
+		parts = append(parts,
+			"-H", shellQuote("Content-Type: application/json"),
+			"--data", shellQuote(string(p.body)),
+		)
+	}
+	return strings.Join(parts, " ")
+}
+
+// DryRunError is returned by terminal operations when the builder is in dry-run mode.
+// It carries the plan that would have been sent and unwraps to utils.ErrDryRun.
+type DryRunError struct {
+	Plan *QueryPlan
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("%s: %s", utils.ErrDryRun.Error(), e.Plan.String())
+}
+
+func (e *DryRunError) Unwrap() error {
+	return utils.ErrDryRun
+}
+
+// PlanFromError extracts the plan from a dry-run error.
+func PlanFromError(err error) (*QueryPlan, bool) {
+	var dryRunErr *DryRunError
+	if errors.As(err, &dryRunErr) {
+		return dryRunErr.Plan, true
+	}
+	return nil, false
+}
+
+// Build validates the query and returns the plan that Get would send.
+// With StrictSchema the columns are checked too; Strict, which needs to
+// fetch the schema, checks them when the plan is executed.
+func (qb *QueryBuilder) Build() (*QueryPlan, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+	if qb.strict && qb.strictTable != nil {
+		if err := qb.checkTableColumns(qb.strictTable); err != nil {
+			return nil, err
+		}
+	}
+	return newQueryPlan("GET", qb.buildEndpoint(), qb.buildParams(), nil, nil), nil
+}
+
+// DryRun switches the builder to dry-run mode: terminal operations validate the
+// query and return a *DryRunError holding the plan instead of sending it.
+//
+//	_, err := query.DryRun().Delete(ctx)
+//	if plan, ok := fluent.PlanFromError(err); ok {
+//	    fmt.Println(plan.Curl())
+//	}
+func (qb *QueryBuilder) DryRun() *QueryBuilder {
+	qb = qb.Clone()
+	qb.dryRun = true
+	return qb
+}
+
+// Explain asks the server for the execution plan of the query instead of its rows.
+func (qb *QueryBuilder) Explain(ctx context.Context) (*utils.Response, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+
+	params := qb.buildParams()
+	params.Set("_explain", "true")
+
+	return qb.execute(ctx, newQueryPlan("GET", qb.buildEndpoint(), params, nil, nil))
+}
+
+// execute sends the plan, or returns it wrapped in a DryRunError in dry-run mode.
+// In strict mode, columns are checked first (even in dry-run mode).
+func (qb *QueryBuilder) execute(ctx context.Context, plan *QueryPlan) (*utils.Response, error) {
+	if qb.strict {
+		if err := qb.checkColumns(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if qb.dryRun {
+		return nil, &DryRunError{Plan: plan}
+	}
+	if len(plan.headers) == 0 {
+		return qb.client.Do(ctx, plan.method, plan.URL(), plan.body)
+	}
+
+	headerClient, ok := qb.client.(builders.HeaderClientInterface)
+	if !ok {
+		return nil, fmt.Errorf("%w: client does not support request headers", utils.ErrInvalidConfiguration)
+	}
+	return headerClient.DoWithHeaders(ctx, plan.method, plan.URL(), plan.body, plan.headers)
+}
+
+func copyValues(values url.Values) url.Values {
+	copied := make(url.Values, len(values))
+	for key, vals := range values {
+		copied[key] = append([]string(nil), vals...)
+	}
+	return copied
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,120 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func TestQueryBuilder_Build(t *testing.T) {
+	plan, err := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Select("id", "total").
+		Where("status", "=", "paid").
+		Limit(5).
+		Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if plan.Method() != "GET" {
+		t.Errorf("Expected GET, got %s", plan.Method())
+	}
+	if plan.Endpoint() != "https://test.example.com/test-datadock/openapi/sales/public/orders" {
+		t.Errorf("Unexpected endpoint: %s", plan.Endpoint())
+	}
+	expectedURL := "https://test.example.com/test-datadock/openapi/sales/public/orders?_limit=5&select=id%2Ctotal&status%5B%3D%5D=paid"
+	if plan.URL() != expectedURL {
+		t.Errorf("Expected URL %s, got %s", expectedURL, plan.URL())
+	}
+	if plan.String() != "GET "+expectedURL {
+		t.Errorf("Unexpected String(): %s", plan.String())
+	}
+	if plan.Body() != nil {
+		t.Errorf("Expected nil body, got %s", plan.Body())
+	}
+
+	// Mutating the returned params must not affect the plan
+	params := plan.Params()
+	params.Set("_limit", "1000")
+	if plan.Params().Get("_limit") != "5" {
+		t.Error("QueryPlan params should be immutable")
+	}
+}
+
+func TestQueryBuilder_BuildValidationError(t *testing.T) {
+	_, err := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "test-datadock"}, nil).
+		Catalog("sales").
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "schema name is required") {
+		t.Errorf("Expected schema validation error, got %v", err)
+	}
+}
+
+func TestQueryBuilder_DryRun(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		t.Error("No request should be sent in dry-run mode")
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+
+	_, err := qb.
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Where("id", "=", 7).
+		DryRun().
+		Put(context.Background(), map[string]any{"status": "it's shipped"})
+
+	if !errors.Is(err, utils.ErrDryRun) {
+		t.Fatalf("Expected ErrDryRun, got %v", err)
+	}
+	plan, ok := PlanFromError(err)
+	if !ok {
+		t.Fatal("Expected plan in dry-run error")
+	}
+	if plan.Method() != "PUT" {
+		t.Errorf("Expected PUT, got %s", plan.Method())
+	}
+	if string(plan.Body()) != `{"status":"it's shipped"}` {
+		t.Errorf("Unexpected body: %s", plan.Body())
+	}
+
+	expectedCurl := `curl -X PUT 'https://test.example.com/test-datadock/openapi/sales/public/orders?id%5B%3D%5D=7' ` +
+		`-H 'Authorization: Bearer '"$HYPERFLUID_TOKEN" -H 'Content-Type: application/json' --data '{"status":"it'\''s shipped"}'`
+	if plan.Curl() != expectedCurl {
+		t.Errorf("Unexpected curl:\n got: %s\nwant: %s", plan.Curl(), expectedCurl)
+	}
+}
+
+func TestQueryBuilder_Explain(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("_explain") != "true" {
+			t.Errorf("Expected _explain=true, got %s", req.URL.RawQuery)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"plan":"TableScan[orders]"}`)),
+		}, nil
+	})
+
+	resp, err := qb.Catalog("sales").Schema("public").Table("orders").Explain(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Data.(map[string]any)["plan"] != "TableScan[orders]" {
+		t.Errorf("Unexpected explain response: %v", resp.Data)
+	}
+}
@@ -12,6 +12,14 @@ import (
 )
 
 // QueryBuilder provides a fluent interface for building and executing queries.
+//
+// QueryBuilder is immutable: every chained call returns a new, independent
+// builder and leaves its receiver untouched. A base query can therefore be
+// branched into variants and shared between goroutines without copying:
+//
+//	orders := client.Catalog("sales").Schema("public").Table("orders")
+//	paid := orders.Where("status", "=", "paid")
+//	open := orders.Where("status", "=", "open") // paid is unaffected
 type QueryBuilder struct {
 	client builders.ClientInterface
 	errors []error
@@ -29,6 +37,23 @@ type QueryBuilder struct {
 	limitVal   int
 	offsetVal  int
 	rawParams  url.Values
+
+	// Aggregation
+	aggregates []builders.Aggregate
+	groupBy    []string
+	having     []builders.Filter
+
+	// Execution
+	dryRun      bool
+	allRows     bool
+	strict      bool
+	strictTable *builders.Table
+}
+
+// validOperators lists the comparison operators accepted by Where and Having.
+var validOperators = map[string]bool{
+	"=": true, ">": true, "<": true, ">=": true, "<=": true,
+	"!=": true, "LIKE": true, "IN": true,
 }
 
 // NewQueryBuilder creates a new QueryBuilder instance.
@@ -44,9 +69,25 @@ func NewQueryBuilder(client interface {
 	}
 }
 
+// Clone returns a deep copy of the builder.
+// Chained calls already clone internally; Clone is useful to snapshot a builder explicitly.
+func (qb *QueryBuilder) Clone() *QueryBuilder {
+	clone := *qb
+	clone.errors = append([]error{}, qb.errors...)
+	clone.selectCols = append([]string(nil), qb.selectCols...)
+	clone.filters = append([]builders.Filter(nil), qb.filters...)
+	clone.orderBy = append([]builders.OrderClause(nil), qb.orderBy...)
+	clone.rawParams = copyValues(qb.rawParams)
+	clone.aggregates = append([]builders.Aggregate(nil), qb.aggregates...)
+	clone.groupBy = append([]string(nil), qb.groupBy...)
+	clone.having = append([]builders.Filter(nil), qb.having...)
+	return &clone
+}
+
 // DataDock sets the data dock ID for the query.
 // If not called, uses the DataDockID from client configuration.
 func (qb *QueryBuilder) DataDock(dataDockID string) *QueryBuilder {
+	qb = qb.Clone()
 	if dataDockID == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("data dock ID cannot be empty"))
 	}
@@ -56,6 +97,7 @@ func (qb *QueryBuilder) DataDock(dataDockID string) *QueryBuilder {
 
 // Catalog sets the catalog name for the query.
 func (qb *QueryBuilder) Catalog(name string) *QueryBuilder {
+	qb = qb.Clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("catalog name cannot be empty"))
 	}
@@ -65,6 +107,7 @@ func (qb *QueryBuilder) Catalog(name string) *QueryBuilder {
 
 // Schema sets the schema name for the query.
 func (qb *QueryBuilder) Schema(name string) *QueryBuilder {
+	qb = qb.Clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("schema name cannot be empty"))
 	}
@@ -74,6 +117,7 @@ func (qb *QueryBuilder) Schema(name string) *QueryBuilder {
 
 // Table sets the table name for the query.
 func (qb *QueryBuilder) Table(name string) *QueryBuilder {
+	qb = qb.Clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("table name cannot be empty"))
 	}
@@ -84,18 +128,21 @@ func (qb *QueryBuilder) Table(name string) *QueryBuilder {
 // Select specifies which columns to retrieve.
 // Can be called multiple times to add more columns.
 func (qb *QueryBuilder) Select(columns ...string) *QueryBuilder {
+	qb = qb.Clone()
 	qb.selectCols = append(qb.selectCols, columns...)
 	return qb
 }
 
+// SelectedColumns returns the columns given to Select, in order, or nil
+// when the query reads every column.
+func (qb *QueryBuilder) SelectedColumns() []string {
+	return append([]string(nil), qb.selectCols...)
+}
+
 // Where adds a filter condition to the query.
 // Supported operators: =, >, <, >=, <=, !=, LIKE, IN
 func (qb *QueryBuilder) Where(column, operator string, value interface{}) *QueryBuilder {
-	validOperators := map[string]bool{
-		"=": true, ">": true, "<": true, ">=": true, "<=": true,
-		"!=": true, "LIKE": true, "IN": true,
-	}
-
+	qb = qb.Clone()
 	if !validOperators[operator] {
 		qb.errors = append(qb.errors, fmt.Errorf("invalid operator '%s'", operator))
 	}
@@ -111,6 +158,7 @@ func (qb *QueryBuilder) Where(column, operator string, value interface{}) *Query
 // OrderBy adds an ORDER BY clause to the query.
 // Direction should be "ASC" or "DESC" (defaults to "ASC" if empty).
 func (qb *QueryBuilder) OrderBy(column, direction string) *QueryBuilder {
+	qb = qb.Clone()
 	if direction == "" {
 		direction = "ASC"
 	}
@@ -130,6 +178,7 @@ func (qb *QueryBuilder) OrderBy(column, direction string) *QueryBuilder {
 
 // Limit sets the maximum number of rows to return.
 func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
+	qb = qb.Clone()
 	if n < 0 {
 		qb.errors = append(qb.errors, fmt.Errorf("limit cannot be negative"))
 		return qb
@@ -140,6 +189,7 @@ func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
 
 // Offset sets the number of rows to skip.
 func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
+	qb = qb.Clone()
 	if n < 0 {
 		qb.errors = append(qb.errors, fmt.Errorf("offset cannot be negative"))
 		return qb
@@ -151,6 +201,7 @@ func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
 // RawParams allows adding custom query parameters.
 // This is an escape hatch for advanced use cases.
 func (qb *QueryBuilder) RawParams(params url.Values) *QueryBuilder {
+	qb = qb.Clone()
 	for key, values := range params {
 		for _, value := range values {
 			qb.rawParams.Add(key, value)
@@ -159,6 +210,17 @@ func (qb *QueryBuilder) RawParams(params url.Values) *QueryBuilder {
 	return qb
 }
 
+// AllRows allows Put, Delete, Update and Replace to run without Where
+// filters, i.e. to affect every row of the table. Without it, unfiltered
+// writes fail with utils.ErrUnfilteredWrite.
+//
+//	_, err := table.AllRows().Delete(ctx) // truncates the table
+func (qb *QueryBuilder) AllRows() *QueryBuilder {
+	qb = qb.Clone()
+	qb.allRows = true
+	return qb
+}
+
 // validate checks that all required fields are set.
 func (qb *QueryBuilder) validate() error {
 	// Check for accumulated errors during building
@@ -170,7 +232,15 @@ func (qb *QueryBuilder) validate() error {
 		return fmt.Errorf("query builder validation failed: %s", strings.Join(errMsgs, "; "))
 	}
 
-	// Check required fields
+	if err := qb.validatePath(); err != nil {
+		return err
+	}
+
+	return qb.validateAggregation()
+}
+
+// validatePath checks that the data dock, catalog, schema and table are set.
+func (qb *QueryBuilder) validatePath() error {
 	if qb.dataDockID == "" {
 		return fmt.Errorf("%w: data dock ID is required", utils.ErrInvalidRequest)
 	}
@@ -183,7 +253,16 @@ func (qb *QueryBuilder) validate() error {
 	if qb.tableName == "" {
 		return fmt.Errorf("%w: table name is required", utils.ErrInvalidRequest)
 	}
+	return nil
+}
 
+// validateFiltered rejects writes that would affect every row, unless the
+// caller opted in with AllRows.
+func (qb *QueryBuilder) validateFiltered(method string) error {
+	if len(qb.filters) == 0 && !qb.allRows {
+		return fmt.Errorf("%w: %s on %s.%s.%s has no Where filters; call AllRows() to confirm",
+			utils.ErrUnfilteredWrite, method, qb.catalogName, qb.schemaName, qb.tableName)
+	}
 	return nil
 }
 
@@ -216,6 +295,22 @@ func (qb *QueryBuilder) buildParams() url.Values {
 		params.Set("select", strings.Join(qb.selectCols, ","))
 	}
 
+	// Add aggregates, GROUP BY and HAVING
+	if len(qb.aggregates) > 0 {
+		var aggParts []string
+		for _, agg := range qb.aggregates {
+			aggParts = append(aggParts, fmt.Sprintf("%s(%s):%s", strings.ToLower(agg.Function), agg.Column, agg.Alias))
+		}
+		params.Set("_aggregate", strings.Join(aggParts, ","))
+	}
+	if len(qb.groupBy) > 0 {
+		params.Set("_group_by", strings.Join(qb.groupBy, ","))
+	}
+	for _, having := range qb.having {
+		paramName := fmt.Sprintf("_having.%s[%s]", having.Column, having.Operator)
+		params.Add(paramName, fmt.Sprintf("%v", having.Value))
+	}
+
 	// Add WHERE filters
 	// TODO - Note: This assumes the API supports filter parameters
 	// Adjust based on actual API capabilities
@@ -253,22 +348,11 @@ func (qb *QueryBuilder) buildParams() url.Values {
 // Get executes the query and returns the results.
 // This is the terminal operation that actually makes the API request.
 func (qb *QueryBuilder) Get(ctx context.Context) (*utils.Response, error) {
-	// Validate the query
-	if err := qb.validate(); err != nil {
+	plan, err := qb.Build()
+	if err != nil {
 		return nil, err
 	}
-
-	// Build endpoint and parameters
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
-
-	// Add parameters to endpoint
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
-	}
-
-	// Execute the request
-	return qb.client.Do(ctx, "GET", endpoint, nil)
+	return qb.execute(ctx, plan)
 }
 
 // Count returns the count of rows matching the query.
@@ -279,24 +363,21 @@ func (qb *QueryBuilder) Count(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
-	// Build endpoint and parameters
-	endpoint := qb.buildEndpoint()
 	params := qb.buildParams()
 
 	// Add count parameter (API-specific)
 	params.Set("count", "exact")
 	params.Set("_limit", "0")
 
-	endpoint += "?" + params.Encode()
-
 	// Execute the request
-	resp, err := qb.client.Do(ctx, "GET", endpoint, nil)
+	resp, err := qb.execute(ctx, newQueryPlan("GET", qb.buildEndpoint(), params, nil, nil))
 	if err != nil {
 		return 0, err
 	}
 
 	// Extract count from response (adjust based on actual API response format)
-	if countVal, ok := resp.Data.(map[string]interface{})["count"]; ok {
+	data, _ := resp.Data.(map[string]interface{})
+	if countVal, ok := data["count"]; ok {
 		if count, ok := countVal.(float64); ok {
 			return int(count), nil
 		}
@@ -305,47 +386,51 @@ func (qb *QueryBuilder) Count(ctx context.Context) (int, error) {
 	return 0, fmt.Errorf("unable to extract count from response")
 }
 
+// Preview returns the number of rows a Delete, Put, Update or Replace with
+// the same filters would affect. It is a Count and does not modify anything.
+//
+//	n, _ := table.Where("status", "=", "cancelled").Preview(ctx)
+//	fmt.Printf("this will delete %d rows\n", n)
+func (qb *QueryBuilder) Preview(ctx context.Context) (int, error) {
+	return qb.Count(ctx)
+}
+
 // Post executes a POST request to insert data.
 func (qb *QueryBuilder) Post(ctx context.Context, data interface{}) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
 
-	endpoint := qb.buildEndpoint()
 	body := utils.JsonMarshal(data)
-
-	return qb.client.Do(ctx, "POST", endpoint, body)
+	return qb.execute(ctx, newQueryPlan("POST", qb.buildEndpoint(), nil, body, nil))
 }
 
 // Put executes a PUT request to update data.
+// The body is sent as-is; prefer Update (partial) or Replace (full row),
+// which make the semantics explicit and report the affected row count.
+// It requires at least one Where filter unless AllRows is set.
 func (qb *QueryBuilder) Put(ctx context.Context, data interface{}) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
-
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
-
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+	if err := qb.validateFiltered("PUT"); err != nil {
+		return nil, err
 	}
 
 	body := utils.JsonMarshal(data)
-	return qb.client.Do(ctx, "PUT", endpoint, body)
+	return qb.execute(ctx, newQueryPlan("PUT", qb.buildEndpoint(), qb.buildParams(), body, nil))
 }
 
 // Delete executes a DELETE request.
+// It requires at least one Where filter unless AllRows is set; use Preview
+// to see how many rows would be deleted.
 func (qb *QueryBuilder) Delete(ctx context.Context) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
-
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
-
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+	if err := qb.validateFiltered("DELETE"); err != nil {
+		return nil, err
 	}
 
-	return qb.client.Do(ctx, "DELETE", endpoint, nil)
+	return qb.execute(ctx, newQueryPlan("DELETE", qb.buildEndpoint(), qb.buildParams(), nil, nil))
 }
@@ -1,8 +1,10 @@
 package fluent
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -470,6 +472,79 @@ func TestQueryBuilder_OrderByDefaultDirection(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_UnfilteredWriteGuard(t *testing.T) {
+	var methods []string
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		methods = append(methods, req.Method)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"count": 12}`)),
+		}, nil
+	}).Catalog("sales").Schema("public").Table("orders")
+	ctx := context.Background()
+
+	if _, err := qb.Delete(ctx); !errors.Is(err, utils.ErrUnfilteredWrite) {
+		t.Errorf("Expected ErrUnfilteredWrite for Delete, got %v", err)
+	}
+	if _, err := qb.Put(ctx, map[string]any{"status": "paid"}); !errors.Is(err, utils.ErrUnfilteredWrite) {
+		t.Errorf("Expected ErrUnfilteredWrite for Put, got %v", err)
+	}
+	if _, err := qb.Update(ctx, map[string]any{"status": "paid"}); !errors.Is(err, utils.ErrUnfilteredWrite) {
+		t.Errorf("Expected ErrUnfilteredWrite for Update, got %v", err)
+	}
+	if _, err := qb.Replace(ctx, map[string]any{"status": "paid"}); !errors.Is(err, utils.ErrUnfilteredWrite) {
+		t.Errorf("Expected ErrUnfilteredWrite for Replace, got %v", err)
+	}
+	if len(methods) != 0 {
+		t.Fatalf("Unfiltered writes should not be sent, got %v", methods)
+	}
+
+	if _, err := qb.Where("status", "=", "cancelled").Delete(ctx); err != nil {
+		t.Errorf("Expected filtered Delete to succeed, got %v", err)
+	}
+	if _, err := qb.AllRows().Delete(ctx); err != nil {
+		t.Errorf("Expected AllRows Delete to succeed, got %v", err)
+	}
+	if _, err := qb.Post(ctx, map[string]any{"id": 1}); err != nil {
+		t.Errorf("Inserts should not require filters, got %v", err)
+	}
+	if strings.Join(methods, ",") != "DELETE,DELETE,POST" {
+		t.Errorf("Unexpected requests: %v", methods)
+	}
+}
+
+func TestQueryBuilder_Preview(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if req.Method != "GET" || query.Get("count") != "exact" {
+			t.Errorf("Expected a count request, got %s %s", req.Method, req.URL)
+		}
+		if query.Get("status[=]") != "cancelled" {
+			t.Errorf("Expected the write filters, got %s", req.URL.RawQuery)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"count": 12}`)),
+		}, nil
+	})
+
+	n, err := qb.Catalog("sales").Schema("public").Table("orders").
+		Where("status", "=", "cancelled").
+		Preview(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 12 {
+		t.Errorf("Expected 12 rows, got %d", n)
+	}
+}
+
 // Test helper to create a mock QueryBuilder
 type mockClient struct {
 	config  utils.Configuration
@@ -477,12 +552,19 @@ type mockClient struct {
 }
 
 func (m *mockClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	return m.DoWithHeaders(ctx, method, endpoint, body, nil)
+}
+
+func (m *mockClient) DoWithHeaders(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error) {
 	if m.handler == nil {
 		// For validation-only tests
 		return &utils.Response{Status: utils.StatusOK}, nil
 	}
 
-	req, _ := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	req, _ := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	for key, values := range headers {
+		req.Header[key] = values
+	}
 	resp, err := m.handler(req)
 	if err != nil {
 		return nil, err
@@ -497,6 +579,7 @@ func (m *mockClient) Do(ctx context.Context, method, endpoint string, body []byt
 			Status:   utils.StatusError,
 			Error:    string(bodyBytes),
 			HTTPCode: resp.StatusCode,
+			Header:   resp.Header,
 		}
 
 		if resp.StatusCode == http.StatusUnauthorized {
@@ -508,12 +591,17 @@ func (m *mockClient) Do(ctx context.Context, method, endpoint string, body []byt
 		if resp.StatusCode == http.StatusNotFound {
 			return response, utils.ErrNotFound
 		}
+		if resp.StatusCode == http.StatusPreconditionFailed {
+			return response, utils.ErrPreconditionFailed
+		}
 		return response, nil
 	}
 
 	// Parse successful response
 	var parsedBody any
-	if len(bodyBytes) > 0 {
+	if utils.IsArrowStream(resp.Header) {
+		parsedBody = bodyBytes
+	} else if len(bodyBytes) > 0 {
 		if err := json.Unmarshal(bodyBytes, &parsedBody); err != nil {
 			return nil, err
 		}
@@ -523,6 +611,7 @@ func (m *mockClient) Do(ctx context.Context, method, endpoint string, body []byt
 		Status:   utils.StatusOK,
 		Data:     parsedBody,
 		HTTPCode: resp.StatusCode,
+		Header:   resp.Header,
 	}, nil
 }
 
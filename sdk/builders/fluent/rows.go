@@ -0,0 +1,145 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// DefaultRowsPageSize is the number of rows Rows fetches per request.
+const DefaultRowsPageSize = 1000
+
+// Rows iterates over the rows of the query, fetching them pageSize at a time
+// (DefaultRowsPageSize if zero) with Limit and Offset, so that large results
+// are never held in memory at once. The builder's own Offset is where
+// iteration starts and its Limit, if any, caps the total number of rows.
+// Add an OrderBy on a unique key for pages to be consistent.
+// Iteration stops at the first error, which is yielded with a nil row.
+//
+//	for row, err := range table.OrderBy("id", "ASC").Rows(ctx, 0) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(row["id"])
+//	}
+func (qb *QueryBuilder) Rows(ctx context.Context, pageSize int) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		if err := qb.validate(); err != nil {
+			yield(nil, err)
+			return
+		}
+		qb, err := qb.resolveStrict(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		qb.paginate(pageSize, func(page *QueryBuilder) (int, bool) {
+			rows, err := page.fetchRows(ctx)
+			if err != nil {
+				yield(nil, err)
+				return 0, false
+			}
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return 0, false
+				}
+			}
+			return len(rows), true
+		})
+	}
+}
+
+// Pages runs the query page by page like Rows, sending headers with each
+// request, and passes each successful response to read. read returns the
+// number of rows of the page and whether to go on; a short page is the
+// last one. It lets callers decode other response formats, as package
+// export does for Arrow streams.
+func (qb *QueryBuilder) Pages(ctx context.Context, pageSize int, headers http.Header, read func(resp *utils.Response) (int, bool)) error {
+	if err := qb.validate(); err != nil {
+		return err
+	}
+	qb, err := qb.resolveStrict(ctx)
+	if err != nil {
+		return err
+	}
+
+	qb.paginate(pageSize, func(page *QueryBuilder) (int, bool) {
+		plan := newQueryPlan("GET", page.buildEndpoint(), page.buildParams(), nil, headers)
+		var resp *utils.Response
+		if resp, err = page.execute(ctx, plan); err != nil {
+			return 0, false
+		}
+		if resp.Status != utils.StatusOK {
+			err = fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+			return 0, false
+		}
+		return read(resp)
+	})
+	return err
+}
+
+// paginate calls fetch with a copy of the query for each page of pageSize
+// rows (DefaultRowsPageSize if zero), from the builder's Offset up to its
+// Limit. fetch returns the number of rows of the page and whether to go
+// on; a short page is the last one.
+func (qb *QueryBuilder) paginate(pageSize int, fetch func(page *QueryBuilder) (int, bool)) {
+	if pageSize <= 0 {
+		pageSize = DefaultRowsPageSize
+	}
+
+	offset, remaining := qb.offsetVal, qb.limitVal
+	for {
+		size := pageSize
+		if qb.limitVal > 0 {
+			if remaining <= 0 {
+				return
+			}
+			size = min(size, remaining)
+		}
+
+		page := qb.Clone()
+		page.limitVal, page.offsetVal = size, offset
+		n, more := fetch(page)
+		if !more || n < size {
+			return
+		}
+		offset += n
+		remaining -= n
+	}
+}
+
+// fetchRows executes the query and returns its rows.
+func (qb *QueryBuilder) fetchRows(ctx context.Context) ([]map[string]any, error) {
+	resp, err := qb.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ResponseRows(resp)
+}
+
+// ResponseRows returns the rows of a JSON query response.
+func ResponseRows(resp *utils.Response) ([]map[string]any, error) {
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	rawRows, ok := resp.GetDataAsSlice()
+	if !ok {
+		return nil, fmt.Errorf("%w: expected a list of rows, got %T", utils.ErrInvalidResponse, resp.Data)
+	}
+	rows := make([]map[string]any, 0, len(rawRows))
+	for i, raw := range rawRows {
+		row, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: row %d is %T, expected an object", utils.ErrInvalidResponse, i, raw)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -1,6 +1,7 @@
 package fluent
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
@@ -383,6 +385,101 @@ func (s *S3Builder) Get(ctx context.Context) (*S3Object, error) {
 	return obj, nil
 }
 
+// putPartSize is the size of the parts of a multipart upload, the minimum
+// S3 accepts for every part but the last.
+const putPartSize = 5 << 20
+
+// Put uploads body to the object, streaming it in multipart chunks so that
+// its size does not need to be known in advance. Bodies smaller than a
+// chunk are sent in a single request. contentType is optional.
+func (s *S3Builder) Put(ctx context.Context, body io.Reader, contentType string) error {
+	if err := s.validate(ctx); err != nil {
+		return err
+	}
+
+	part := make([]byte, putPartSize)
+	n, err := io.ReadFull(body, part)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key),
+			Body:   bytes.NewReader(part[:n]),
+		}
+		if contentType != "" {
+			input.ContentType = aws.String(contentType)
+		}
+		if _, err := s.s3Client.PutObject(ctx, input); err != nil {
+			return fmt.Errorf("failed to put object to MinIO: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read object body: %w", err)
+	}
+	return s.putMultipart(ctx, body, contentType, part)
+}
+
+// putMultipart uploads first, a full part already read, and the rest of
+// body as a multipart upload, which is aborted if anything fails.
+func (s *S3Builder) putMultipart(ctx context.Context, body io.Reader, contentType string, first []byte) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	upload, err := s.s3Client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to put object to MinIO: %w", err)
+	}
+
+	if err := s.uploadParts(ctx, body, upload.UploadId, first); err != nil {
+		// The upload is dropped even if ctx is done, or its parts are kept.
+		_, _ = s.s3Client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(s.bucket),
+			Key:      aws.String(s.key),
+			UploadId: upload.UploadId,
+		})
+		return err
+	}
+	return nil
+}
+
+func (s *S3Builder) uploadParts(ctx context.Context, body io.Reader, uploadID *string, part []byte) error {
+	var parts []types.CompletedPart
+	n := len(part)
+	for number := int32(1); n > 0; number++ {
+		uploaded, err := s.s3Client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(s.bucket),
+			Key:        aws.String(s.key),
+			UploadId:   uploadID,
+			PartNumber: aws.Int32(number),
+			Body:       bytes.NewReader(part[:n]),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put object to MinIO: %w", err)
+		}
+		parts = append(parts, types.CompletedPart{ETag: uploaded.ETag, PartNumber: aws.Int32(number)})
+
+		n, err = io.ReadFull(body, part)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("failed to read object body: %w", err)
+		}
+	}
+
+	_, err := s.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(s.key),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object to MinIO: %w", err)
+	}
+	return nil
+}
+
 // validateList checks validation errors and runs STS if needed (no key required)
 func (s *S3Builder) validateList(ctx context.Context) error {
 	if len(s.errors) > 0 {
@@ -0,0 +1,331 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// NamedArg is a named SQL parameter bound to a :name placeholder.
+type NamedArg struct {
+	Name  string
+	Value any
+}
+
+// Named creates a named SQL parameter.
+//
+//	client.SQL(dataDockID).Query(ctx,
+//	    "SELECT * FROM sales.public.orders WHERE region = :region",
+//	    fluent.Named("region", "EU"))
+func Named(name string, value any) NamedArg {
+	return NamedArg{Name: name, Value: value}
+}
+
+// SQLColumn describes a column of a SQL result set.
+type SQLColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+// SQLResult holds the result set of a SQL query.
+type SQLResult struct {
+	Columns []SQLColumn
+	Rows    []map[string]any
+}
+
+// ColumnNames returns the result column names in server order.
+func (r *SQLResult) ColumnNames() []string {
+	names := make([]string, len(r.Columns))
+	for i, column := range r.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// All iterates over the result rows with their index.
+func (r *SQLResult) All() iter.Seq2[int, map[string]any] {
+	return func(yield func(int, map[string]any) bool) {
+		for i, row := range r.Rows {
+			if !yield(i, row) {
+				return
+			}
+		}
+	}
+}
+
+// Scan decodes the rows into target, which must be a pointer to a slice of
+// structs or maps (JSON tags are honoured).
+func (r *SQLResult) Scan(target any) error {
+	return utils.UnmarshalData(r.Rows, target)
+}
+
+// SQLBuilder executes raw SQL against a data dock.
+// Parameters are always sent separately from the SQL text and bound server-side.
+type SQLBuilder struct {
+	client     builders.ClientInterface
+	dataDockID string
+}
+
+// NewSQLBuilder creates a new SQLBuilder for the given data dock.
+// If dataDockID is empty, the DataDockID from client configuration is used.
+func NewSQLBuilder(client builders.ClientInterface, dataDockID string) *SQLBuilder {
+	if dataDockID == "" {
+		dataDockID = client.GetConfig().DataDockID
+	}
+	return &SQLBuilder{
+		client:     client,
+		dataDockID: dataDockID,
+	}
+}
+
+// Query executes a SQL query and returns its result set.
+// Use ? placeholders with positional args, or :name placeholders with Named args.
+// The two styles cannot be mixed in one query.
+func (s *SQLBuilder) Query(ctx context.Context, query string, args ...any) (*SQLResult, error) {
+	body, err := s.buildBody(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, "POST", s.buildEndpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	return decodeSQLResult(resp.Data)
+}
+
+// BufferedRows executes a SQL query and iterates over its rows, yielding
+// them in the same form as QueryBuilder.Rows. Unlike QueryBuilder.Rows it
+// does not page: the SQL endpoint returns the whole result set in a single
+// response, which is held in memory (as with Query) before the first row is
+// yielded. Add a LIMIT to the query to bound it. Iteration stops at the
+// first error, which is yielded with a nil row.
+//
+//	for row, err := range client.SQL(dataDockID).BufferedRows(ctx, "SELECT id FROM sales.public.orders LIMIT 1000") {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(row["id"])
+//	}
+func (s *SQLBuilder) BufferedRows(ctx context.Context, query string, args ...any) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		result, err := s.Query(ctx, query, args...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, row := range result.Rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// QueryInto executes a SQL query and decodes the rows into target.
+func (s *SQLBuilder) QueryInto(ctx context.Context, target any, query string, args ...any) error {
+	result, err := s.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return result.Scan(target)
+}
+
+// buildEndpoint constructs the SQL endpoint URL.
+func (s *SQLBuilder) buildEndpoint() string {
+	return fmt.Sprintf(
+		"%s/%s/sql",
+		strings.TrimRight(s.client.GetConfig().BaseURL, "/"),
+		url.PathEscape(s.dataDockID),
+	)
+}
+
+// buildBody validates the placeholders against args and encodes the request body.
+func (s *SQLBuilder) buildBody(query string, args []any) ([]byte, error) {
+	if s.dataDockID == "" {
+		return nil, fmt.Errorf("%w: data dock ID is required", utils.ErrInvalidRequest)
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: SQL query cannot be empty", utils.ErrInvalidRequest)
+	}
+
+	positional := []any{}
+	named := map[string]any{}
+	for _, arg := range args {
+		if n, ok := arg.(NamedArg); ok {
+			if n.Name == "" {
+				return nil, fmt.Errorf("%w: named parameter must have a name", utils.ErrInvalidRequest)
+			}
+			if _, dup := named[n.Name]; dup {
+				return nil, fmt.Errorf("%w: duplicate named parameter '%s'", utils.ErrInvalidRequest, n.Name)
+			}
+			named[n.Name] = n.Value
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	if len(positional) > 0 && len(named) > 0 {
+		return nil, fmt.Errorf("%w: cannot mix positional and named parameters", utils.ErrInvalidRequest)
+	}
+
+	questionMarks, names := scanPlaceholders(query)
+	if len(named) > 0 || len(names) > 0 {
+		if questionMarks > 0 {
+			return nil, fmt.Errorf("%w: cannot mix ? and :name placeholders", utils.ErrInvalidRequest)
+		}
+		for _, name := range names {
+			if _, ok := named[name]; !ok {
+				return nil, fmt.Errorf("%w: missing value for parameter ':%s'", utils.ErrInvalidRequest, name)
+			}
+		}
+		if len(named) > len(uniqueStrings(names)) {
+			return nil, fmt.Errorf("%w: %d named parameters provided but query uses %d", utils.ErrInvalidRequest, len(named), len(uniqueStrings(names)))
+		}
+	} else if questionMarks != len(positional) {
+		return nil, fmt.Errorf("%w: query has %d placeholders but %d arguments were provided", utils.ErrInvalidRequest, questionMarks, len(positional))
+	}
+
+	request := map[string]any{"query": query}
+	if len(named) > 0 {
+		request["named_parameters"] = named
+	} else {
+		request["parameters"] = positional
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot encode SQL parameters: %w", utils.ErrInvalidRequest, err)
+	}
+	return body, nil
+}
+
+// scanPlaceholders counts ? placeholders and collects :name placeholders,
+// ignoring string literals, quoted identifiers, -- and /* */ comments and
+// :: casts.
+func scanPlaceholders(query string) (int, []string) {
+	questionMarks := 0
+	var names []string
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			// Skip to the closing quote; doubled quotes are escapes
+			for i++; i < len(query); i++ {
+				if query[i] == c {
+					if i+1 < len(query) && query[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			// Skip to the end of the block comment (or of the query)
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return questionMarks, names
+			}
+			i += 2 + end + 1
+		case c == '?':
+			questionMarks++
+		case c == ':':
+			if i+1 < len(query) && query[i+1] == ':' {
+				i++ // :: cast
+				continue
+			}
+			// Names start with a letter or underscore, so that array
+			// slices such as arr[1:2] are not parameters
+			j := i + 1
+			if j == len(query) || !isIdentStart(query[j]) {
+				continue
+			}
+			for j < len(query) && isIdentChar(query[j]) {
+				j++
+			}
+			if j > i+1 {
+				names = append(names, query[i+1:j])
+				i = j - 1
+			}
+		}
+	}
+
+	return questionMarks, names
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// decodeSQLResult parses {"columns": [...], "rows": [...]} where rows are
+// either objects or arrays of values in column order.
+func decodeSQLResult(data any) (*SQLResult, error) {
+	payload, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected SQL response type %T", data)
+	}
+
+	result := &SQLResult{}
+	if columns, ok := payload["columns"]; ok && columns != nil {
+		if err := utils.UnmarshalData(columns, &result.Columns); err != nil {
+			return nil, fmt.Errorf("invalid SQL column metadata: %w", err)
+		}
+	}
+
+	var rawRows []any
+	if rows, ok := payload["rows"]; ok && rows != nil {
+		if rawRows, ok = rows.([]any); !ok {
+			return nil, fmt.Errorf("%w: SQL rows must be a list, got %T", utils.ErrInvalidResponse, rows)
+		}
+	}
+	result.Rows = make([]map[string]any, 0, len(rawRows))
+	for i, raw := range rawRows {
+		switch row := raw.(type) {
+		case map[string]any:
+			result.Rows = append(result.Rows, row)
+		case []any:
+			if len(row) != len(result.Columns) {
+				return nil, fmt.Errorf("SQL row %d has %d values but %d columns were described", i, len(row), len(result.Columns))
+			}
+			rowMap := make(map[string]any, len(row))
+			for j, value := range row {
+				rowMap[result.Columns[j].Name] = value
+			}
+			result.Rows = append(result.Rows, rowMap)
+		default:
+			return nil, fmt.Errorf("SQL row %d has unexpected type %T", i, raw)
+		}
+	}
+
+	return result, nil
+}
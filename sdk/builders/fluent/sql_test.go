@@ -0,0 +1,197 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func newTestSQLBuilder(dataDockID string, handler func(*http.Request) (*http.Response, error)) *SQLBuilder {
+	return NewSQLBuilder(&mockClient{
+		config: utils.Configuration{
+			BaseURL:    "https://test.example.com",
+			Token:      "test-token",
+			DataDockID: "config-datadock",
+		},
+		handler: handler,
+	}, dataDockID)
+}
+
+func TestSQLBuilder_PositionalParameters(t *testing.T) {
+	sql := newTestSQLBuilder("test-datadock", func(req *http.Request) (*http.Response, error) {
+		if req.Method != "POST" {
+			t.Errorf("Expected POST, got %s", req.Method)
+		}
+		if req.URL.Path != "/test-datadock/sql" {
+			t.Errorf("Expected path /test-datadock/sql, got %s", req.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body["query"] != "SELECT * FROM a JOIN b ON a.id = b.a_id WHERE a.id = ? AND b.note != '?'" {
+			t.Errorf("SQL text must be sent untouched, got %v", body["query"])
+		}
+		params, _ := body["parameters"].([]any)
+		if len(params) != 1 || params[0] != float64(42) {
+			t.Errorf("Expected parameters [42], got %v", body["parameters"])
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`{
+				"columns": [{"name":"id","type":"bigint","nullable":false},{"name":"name","type":"varchar","nullable":true}],
+				"rows": [[42, "Alice"]]
+			}`)),
+		}, nil
+	})
+
+	result, err := sql.Query(context.Background(),
+		"SELECT * FROM a JOIN b ON a.id = b.a_id WHERE a.id = ? AND b.note != '?'", 42)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := strings.Join(result.ColumnNames(), ","); got != "id,name" {
+		t.Errorf("Expected columns id,name, got %s", got)
+	}
+	if result.Columns[1].Type != "varchar" || !result.Columns[1].Nullable {
+		t.Errorf("Unexpected column metadata: %+v", result.Columns[1])
+	}
+
+	var rows []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := result.Scan(&rows); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != 42 || rows[0].Name != "Alice" {
+		t.Errorf("Unexpected rows: %+v", rows)
+	}
+}
+
+func TestSQLBuilder_NamedParameters(t *testing.T) {
+	sql := newTestSQLBuilder("", func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/config-datadock/sql" {
+			t.Errorf("Expected config data dock in path, got %s", req.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		named, _ := body["named_parameters"].(map[string]any)
+		if named["region"] != "EU" || named["min"] != float64(10) {
+			t.Errorf("Unexpected named parameters: %v", body["named_parameters"])
+		}
+		if _, ok := body["parameters"]; ok {
+			t.Error("Positional parameters must not be sent with named parameters")
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"columns":[{"name":"total"}],"rows":[{"total":3}]}`)),
+		}, nil
+	})
+
+	result, err := sql.Query(context.Background(),
+		"SELECT count(*)::int AS total FROM orders WHERE region = :region AND amount > :min",
+		Named("region", "EU"), Named("min", 10))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i, row := range result.All() {
+		if i != 0 || row["total"] != float64(3) {
+			t.Errorf("Unexpected row %d: %v", i, row)
+		}
+	}
+}
+
+func TestSQLBuilder_ParameterValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []any
+		errorMsg string
+	}{
+		{"empty query", "  ", nil, "SQL query cannot be empty"},
+		{"too few args", "SELECT * FROM t WHERE a = ? AND b = ?", []any{1}, "query has 2 placeholders but 1 arguments"},
+		{"too many args", "SELECT * FROM t", []any{1}, "query has 0 placeholders but 1 arguments"},
+		{"mixed args", "SELECT * FROM t WHERE a = ?", []any{1, Named("b", 2)}, "cannot mix positional and named parameters"},
+		{"mixed placeholders", "SELECT * FROM t WHERE a = ? AND b = :b", []any{Named("b", 2)}, "cannot mix ? and :name placeholders"},
+		{"missing named", "SELECT * FROM t WHERE a = :a", []any{Named("b", 2)}, "missing value for parameter ':a'"},
+		{"duplicate named", "SELECT * FROM t WHERE a = :a", []any{Named("a", 1), Named("a", 2)}, "duplicate named parameter 'a'"},
+	}
+
+	sql := newTestSQLBuilder("test-datadock", func(req *http.Request) (*http.Response, error) {
+		t.Error("No request should be sent for invalid queries")
+		return nil, nil
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sql.Query(context.Background(), tt.query, tt.args...)
+			if err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error to contain '%s', got '%s'", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestScanPlaceholders(t *testing.T) {
+	tests := []struct {
+		query         string
+		questionMarks int
+		names         string
+	}{
+		{"SELECT * FROM t WHERE a = ? AND b = '?'", 1, ""},
+		{"SELECT * /* why? :not */ FROM t WHERE id = ?", 1, ""},
+		{"SELECT * FROM t -- what?\nWHERE id = ?", 1, ""},
+		{"SELECT arr[1:2], x::int FROM t WHERE id = ?", 1, ""},
+		{"SELECT * FROM t WHERE a = :a AND b = :_b2", 0, "a,_b2"},
+		{"SELECT 1 /* unterminated ?", 0, ""},
+	}
+	for _, tt := range tests {
+		questionMarks, names := scanPlaceholders(tt.query)
+		if questionMarks != tt.questionMarks || strings.Join(names, ",") != tt.names {
+			t.Errorf("scanPlaceholders(%q) = %d, %v", tt.query, questionMarks, names)
+		}
+	}
+}
+
+func TestSQLBuilder_BufferedRows(t *testing.T) {
+	body := `{"columns":[{"name":"id"}],"rows":[[1],[2]]}`
+	sql := newTestSQLBuilder("test-datadock", func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	var ids []any
+	for row, err := range sql.BufferedRows(context.Background(), "SELECT id FROM t") {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, row["id"])
+	}
+	if len(ids) != 2 || ids[1] != float64(2) {
+		t.Errorf("Expected ids 1 and 2, got %v", ids)
+	}
+
+	body = `{"columns":[{"name":"id"}],"rows":{"id":1}}`
+	for _, err := range sql.BufferedRows(context.Background(), "SELECT id FROM t") {
+		if !errors.Is(err, utils.ErrInvalidResponse) {
+			t.Errorf("Expected ErrInvalidResponse for malformed rows, got %v", err)
+		}
+	}
+}
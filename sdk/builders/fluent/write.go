@@ -0,0 +1,225 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// WriteOption configures Update, Replace and Upsert.
+type WriteOption func(*writeOptions)
+
+type writeOptions struct {
+	onConflict []string
+	ifMatch    string
+	versionCol string
+	versionVal interface{}
+	hasVersion bool
+	returnRows bool
+}
+
+// OnConflict sets the unique columns used to detect existing rows in Upsert.
+func OnConflict(columns ...string) WriteOption {
+	return func(o *writeOptions) {
+		o.onConflict = append(o.onConflict, columns...)
+	}
+}
+
+// IfMatch makes the write conditional on the row's current ETag.
+// If the row changed since the ETag was read, the write fails with
+// utils.ErrPreconditionFailed.
+func IfMatch(etag string) WriteOption {
+	return func(o *writeOptions) {
+		o.ifMatch = etag
+	}
+}
+
+// IfVersion makes the write conditional on a version column (optimistic
+// concurrency). If no row matches the filters and the expected version, the
+// write fails with utils.ErrPreconditionFailed. The server is asked for an
+// exact count of the written rows; if it does not report one, the write
+// fails with utils.ErrInvalidResponse since the match cannot be confirmed.
+func IfVersion(column string, version interface{}) WriteOption {
+	return func(o *writeOptions) {
+		o.versionCol = column
+		o.versionVal = version
+		o.hasVersion = true
+	}
+}
+
+// Returning asks the server to return the written rows in WriteResult.Rows.
+func Returning() WriteOption {
+	return func(o *writeOptions) {
+		o.returnRows = true
+	}
+}
+
+// WriteResult describes the outcome of Update, Replace or Upsert.
+type WriteResult struct {
+	Affected int              // Rows written, or -1 if the server did not report it
+	Rows     []map[string]any // Written rows (only with Returning)
+	ETag     string           // New ETag, if the server returned one
+	Response *utils.Response
+}
+
+// Update partially updates the rows matching the filters: only the fields
+// present in patch are changed (HTTP PATCH). Like Delete, it requires a
+// Where filter unless AllRows is set.
+//
+//	result, err := client.Catalog("sales").Schema("public").Table("orders").
+//	    Where("id", "=", 42).
+//	    Update(ctx, map[string]any{"status": "shipped"}, fluent.IfVersion("version", 3))
+func (qb *QueryBuilder) Update(ctx context.Context, patch interface{}, opts ...WriteOption) (*WriteResult, error) {
+	return qb.write(ctx, "PATCH", patch, opts)
+}
+
+// Replace replaces the rows matching the filters with row: fields absent
+// from row are reset (HTTP PUT).
+func (qb *QueryBuilder) Replace(ctx context.Context, row interface{}, opts ...WriteOption) (*WriteResult, error) {
+	return qb.write(ctx, "PUT", row, opts)
+}
+
+// Upsert inserts rows, updating existing rows that conflict on the columns
+// given with OnConflict (which is required).
+//
+//	result, err := table.Upsert(ctx, rows, fluent.OnConflict("id"))
+func (qb *QueryBuilder) Upsert(ctx context.Context, rows interface{}, opts ...WriteOption) (*WriteResult, error) {
+	return qb.write(ctx, "POST", rows, opts)
+}
+
+// write builds and executes a conditional write.
+func (qb *QueryBuilder) write(ctx context.Context, method string, data interface{}, opts []WriteOption) (*WriteResult, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+	if method != "POST" {
+		if err := qb.validateFiltered(method); err != nil {
+			return nil, err
+		}
+	}
+
+	options := &writeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	plan, err := qb.writePlan(method, data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := qb.execute(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	result := parseWriteResult(resp)
+	if options.hasVersion {
+		switch result.Affected {
+		case 0:
+			return result, fmt.Errorf("%w: no row matched %s = %v", utils.ErrPreconditionFailed, options.versionCol, options.versionVal)
+		case -1:
+			return result, fmt.Errorf("%w: the server did not report how many rows matched %s = %v", utils.ErrInvalidResponse, options.versionCol, options.versionVal)
+		}
+	}
+	return result, nil
+}
+
+// writePlan builds the request for a write without sending it.
+func (qb *QueryBuilder) writePlan(method string, data interface{}, options *writeOptions) (*QueryPlan, error) {
+	params := qb.buildParams()
+	headers := http.Header{}
+	var prefer []string
+
+	if method == "POST" {
+		if len(options.onConflict) == 0 {
+			return nil, fmt.Errorf("%w: upsert requires OnConflict columns", utils.ErrInvalidRequest)
+		}
+		if options.hasVersion || options.ifMatch != "" {
+			return nil, fmt.Errorf("%w: upsert does not support IfMatch or IfVersion", utils.ErrInvalidRequest)
+		}
+		// Inserts are not filtered; only the conflict target is sent
+		params = copyValues(qb.rawParams)
+		params.Set("on_conflict", strings.Join(options.onConflict, ","))
+		prefer = append(prefer, "resolution=merge-duplicates")
+	} else if len(options.onConflict) > 0 {
+		return nil, fmt.Errorf("%w: OnConflict only applies to Upsert", utils.ErrInvalidRequest)
+	}
+
+	if options.hasVersion {
+		if options.versionCol == "" {
+			return nil, fmt.Errorf("%w: version column cannot be empty", utils.ErrInvalidRequest)
+		}
+		params.Add(fmt.Sprintf("%s[=]", options.versionCol), fmt.Sprintf("%v", options.versionVal))
+		// An unreported count must not pass for a matched version
+		prefer = append(prefer, "count=exact")
+	}
+	if options.ifMatch != "" {
+		headers.Set("If-Match", options.ifMatch)
+	}
+	if options.returnRows {
+		prefer = append(prefer, "return=representation")
+	}
+	if len(prefer) > 0 {
+		headers.Set("Prefer", strings.Join(prefer, ","))
+	}
+
+	return newQueryPlan(method, qb.buildEndpoint(), params, utils.JsonMarshal(data), headers), nil
+}
+
+// parseWriteResult extracts the affected row count and returned rows.
+// Supported shapes: an array of rows, or an object with "affected_rows"
+// (or "count") and optional "rows". Otherwise the total of a Content-Range
+// header ("0-4/5" or "*/5"), sent for Prefer: count=exact, is used.
+func parseWriteResult(resp *utils.Response) *WriteResult {
+	result := &WriteResult{Affected: -1, Response: resp}
+	if resp.Header != nil {
+		result.ETag = resp.Header.Get("ETag")
+	}
+
+	switch data := resp.Data.(type) {
+	case []any:
+		result.Rows = toRowMaps(data)
+		result.Affected = len(data)
+	case map[string]any:
+		for _, key := range []string{"affected_rows", "count"} {
+			if n, ok := data[key].(float64); ok {
+				result.Affected = int(n)
+				break
+			}
+		}
+		if rows, ok := data["rows"].([]any); ok {
+			result.Rows = toRowMaps(rows)
+			if result.Affected < 0 {
+				result.Affected = len(rows)
+			}
+		}
+	}
+	if result.Affected < 0 && resp.Header != nil {
+		contentRange := resp.Header.Get("Content-Range")
+		if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+			if n, err := strconv.Atoi(contentRange[i+1:]); err == nil {
+				result.Affected = n
+			}
+		}
+	}
+
+	return result
+}
+
+func toRowMaps(rows []any) []map[string]any {
+	rowMaps := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		if m, ok := row.(map[string]any); ok {
+			rowMaps = append(rowMaps, m)
+		}
+	}
+	return rowMaps
+}
@@ -0,0 +1,214 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// newWriteTestQueryBuilder returns a builder on sales.public.orders whose
+// requests are recorded in *captured and answered with status and body.
+func newWriteTestQueryBuilder(captured **http.Request, status int, body string, header http.Header) *QueryBuilder {
+	return newTestQueryBuilder(utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+	}, func(req *http.Request) (*http.Response, error) {
+		*captured = req
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}).Catalog("sales").Schema("public").Table("orders")
+}
+
+func TestQueryBuilder_Update(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, `{"affected_rows": 2}`, nil)
+
+	result, err := qb.Where("status", "=", "pending").Update(context.Background(), map[string]any{"status": "shipped"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if req.Method != "PATCH" {
+		t.Errorf("Expected PATCH, got %s", req.Method)
+	}
+	if req.URL.Query().Get("status[=]") != "pending" {
+		t.Errorf("Expected filter in query, got %s", req.URL.RawQuery)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"status":"shipped"}` {
+		t.Errorf("Unexpected body: %s", body)
+	}
+	if result.Affected != 2 {
+		t.Errorf("Expected 2 affected rows, got %d", result.Affected)
+	}
+}
+
+func TestQueryBuilder_Replace(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, ``, nil)
+
+	result, err := qb.Where("id", "=", 1).Replace(context.Background(), map[string]any{"id": 1, "status": "paid"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.Method != "PUT" {
+		t.Errorf("Expected PUT, got %s", req.Method)
+	}
+	if result.Affected != -1 {
+		t.Errorf("Expected unknown affected count, got %d", result.Affected)
+	}
+}
+
+func TestQueryBuilder_Upsert(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusCreated, `[{"id": 1}, {"id": 2}]`, nil)
+
+	result, err := qb.Where("ignored", "=", 1).Upsert(context.Background(),
+		[]map[string]any{{"id": 1}, {"id": 2}}, OnConflict("id"), Returning())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if req.Method != "POST" {
+		t.Errorf("Expected POST, got %s", req.Method)
+	}
+	if req.URL.RawQuery != "on_conflict=id" {
+		t.Errorf("Expected only on_conflict in query, got %s", req.URL.RawQuery)
+	}
+	if prefer := req.Header.Get("Prefer"); prefer != "resolution=merge-duplicates,return=representation" {
+		t.Errorf("Unexpected Prefer header: %s", prefer)
+	}
+	if result.Affected != 2 || len(result.Rows) != 2 || result.Rows[1]["id"] != float64(2) {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestQueryBuilder_WriteOptionValidation(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, `{}`, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		write func() error
+	}{
+		{"upsert without OnConflict", func() error {
+			_, err := qb.Upsert(ctx, []any{})
+			return err
+		}},
+		{"upsert with IfMatch", func() error {
+			_, err := qb.Upsert(ctx, []any{}, OnConflict("id"), IfMatch(`"v1"`))
+			return err
+		}},
+		{"update with OnConflict", func() error {
+			_, err := qb.Where("id", "=", 1).Update(ctx, map[string]any{}, OnConflict("id"))
+			return err
+		}},
+		{"empty version column", func() error {
+			_, err := qb.Where("id", "=", 1).Update(ctx, map[string]any{}, IfVersion("", 1))
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.write(); !errors.Is(err, utils.ErrInvalidRequest) {
+				t.Errorf("Expected ErrInvalidRequest, got %v", err)
+			}
+		})
+	}
+	if req != nil {
+		t.Error("Invalid writes should not be sent")
+	}
+}
+
+func TestQueryBuilder_UpdateIfMatch(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, `{"affected_rows": 1}`, http.Header{"Etag": {`"v2"`}})
+
+	result, err := qb.Where("id", "=", 1).Update(context.Background(), map[string]any{"total": 10}, IfMatch(`"v1"`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.Header.Get("If-Match") != `"v1"` {
+		t.Errorf("Expected If-Match header, got %q", req.Header.Get("If-Match"))
+	}
+	if result.ETag != `"v2"` {
+		t.Errorf("Expected new ETag, got %q", result.ETag)
+	}
+
+	qb = newWriteTestQueryBuilder(&req, http.StatusPreconditionFailed, `etag mismatch`, nil)
+	_, err = qb.Where("id", "=", 1).Update(context.Background(), map[string]any{"total": 10}, IfMatch(`"v1"`))
+	if !errors.Is(err, utils.ErrPreconditionFailed) {
+		t.Errorf("Expected ErrPreconditionFailed, got %v", err)
+	}
+}
+
+func TestQueryBuilder_UpdateIfVersion(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, `{"affected_rows": 1}`, nil)
+
+	if _, err := qb.Where("id", "=", 1).Update(context.Background(), map[string]any{"version": 4}, IfVersion("version", 3)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.URL.Query().Get("version[=]") != "3" || req.URL.Query().Get("id[=]") != "1" {
+		t.Errorf("Expected id and version filters, got %s", req.URL.RawQuery)
+	}
+
+	qb = newWriteTestQueryBuilder(&req, http.StatusOK, `{"affected_rows": 0}`, nil)
+	_, err := qb.Where("id", "=", 1).Update(context.Background(), map[string]any{"version": 4}, IfVersion("version", 3))
+	if !errors.Is(err, utils.ErrPreconditionFailed) {
+		t.Errorf("Expected ErrPreconditionFailed for stale version, got %v", err)
+	}
+}
+
+func TestQueryBuilder_UpdateIfVersionRequiresCount(t *testing.T) {
+	var req *http.Request
+	update := func(qb *QueryBuilder) (*WriteResult, error) {
+		return qb.Where("id", "=", 1).Update(context.Background(), map[string]any{"version": 4}, IfVersion("version", 3))
+	}
+
+	result, err := update(newWriteTestQueryBuilder(&req, http.StatusOK, ``, http.Header{"Content-Range": {"*/1"}}))
+	if err != nil || result.Affected != 1 {
+		t.Fatalf("Expected 1 affected row from Content-Range, got %+v, %v", result, err)
+	}
+	if prefer := req.Header.Get("Prefer"); prefer != "count=exact" {
+		t.Errorf("Expected Prefer: count=exact, got %q", prefer)
+	}
+
+	if _, err := update(newWriteTestQueryBuilder(&req, http.StatusOK, ``, http.Header{"Content-Range": {"*/0"}})); !errors.Is(err, utils.ErrPreconditionFailed) {
+		t.Errorf("Expected ErrPreconditionFailed for a zero Content-Range total, got %v", err)
+	}
+	if _, err := update(newWriteTestQueryBuilder(&req, http.StatusOK, ``, nil)); !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Errorf("Expected ErrInvalidResponse when no count is reported, got %v", err)
+	}
+}
+
+func TestQueryBuilder_WriteDryRun(t *testing.T) {
+	var req *http.Request
+	qb := newWriteTestQueryBuilder(&req, http.StatusOK, `{}`, nil)
+
+	_, err := qb.DryRun().Where("id", "=", 1).Update(context.Background(), map[string]any{"total": 10}, IfMatch(`"v1"`), Returning())
+	plan, ok := PlanFromError(err)
+	if !ok {
+		t.Fatalf("Expected a dry-run plan, got %v", err)
+	}
+	if req != nil {
+		t.Error("Dry run should not send the request")
+	}
+
+	curl := plan.Curl()
+	for _, want := range []string{"-X PATCH", `'If-Match: "v1"'`, "'Prefer: return=representation'", `--data '{"total":10}'`} {
+		if !strings.Contains(curl, want) {
+			t.Errorf("Expected %q in curl command: %s", want, curl)
+		}
+	}
+}
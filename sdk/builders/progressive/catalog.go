@@ -3,15 +3,24 @@ package progressive
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Typed catalog metadata, shared with the fluent API.
+type (
+	Catalog = builders.Catalog
+	Schema  = builders.Schema
+	Table   = builders.Table
+	Column  = builders.Column
 )
 
 // CatalogBuilder represents a catalog context.
 // Available methods:
 //   - Schema(name) - Navigate to a specific schema
 //   - ListSchemas(ctx) - List all schemas in this catalog
+//   - Get(ctx) - Get the catalog metadata as a typed Catalog
 type CatalogBuilder struct {
 	client      builders.ClientInterface
 	orgID       string
@@ -31,38 +40,24 @@ func (c *CatalogBuilder) Schema(schemaName string) *SchemaBuilder {
 }
 
 // ListSchemas retrieves all schemas in this catalog.
-// This parses the catalog metadata to extract schemas.
+// It returns utils.ErrNotFound if the catalog does not exist.
 func (c *CatalogBuilder) ListSchemas(ctx context.Context) ([]string, error) {
-	// Get full catalog metadata
-	endpoint := fmt.Sprintf("%s/data-docks/%s/catalog",
-		c.client.GetConfig().BaseURL,
-		url.PathEscape(c.dataDockID),
-	)
-
-	resp, err := c.client.Do(ctx, "GET", endpoint, nil)
+	catalog, err := c.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return catalog.SchemaNames(), nil
+}
 
-	// Extract schemas for this catalog
-	var schemas []string
-	if catalogs, ok := resp.Data.(map[string]interface{})["catalogs"].([]interface{}); ok {
-		for _, cat := range catalogs {
-			if catMap, ok := cat.(map[string]interface{}); ok {
-				if catMap["catalog_name"] == c.catalogName {
-					if schemaList, ok := catMap["schemas"].([]interface{}); ok {
-						for _, s := range schemaList {
-							if sMap, ok := s.(map[string]interface{}); ok {
-								if name, ok := sMap["schema_name"].(string); ok {
-									schemas = append(schemas, name)
-								}
-							}
-						}
-					}
-				}
-			}
-		}
+// Get retrieves the metadata of this catalog (schemas, tables, columns).
+func (c *CatalogBuilder) Get(ctx context.Context) (*Catalog, error) {
+	catalogs, err := builders.FetchCatalogs(ctx, c.client, c.dataDockID)
+	if err != nil {
+		return nil, err
 	}
-
-	return schemas, nil
+	catalog, ok := builders.FindCatalog(catalogs, c.catalogName)
+	if !ok {
+		return nil, fmt.Errorf("%w: catalog %q", utils.ErrNotFound, c.catalogName)
+	}
+	return catalog, nil
 }
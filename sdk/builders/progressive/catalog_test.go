@@ -0,0 +1,82 @@
+package progressive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const testCatalogPayload = `{"catalogs": [{
+	"catalog_name": "sales",
+	"schemas": [
+		{"schema_name": "public", "tables": [
+			{"table_name": "orders", "columns": [{"column_name": "id", "data_type": "bigint", "is_nullable": false}]},
+			{"table_name": "order_lines", "columns": []}
+		]},
+		{"schema_name": "archive", "tables": []}
+	]
+}]}`
+
+func newCatalogTestDataDock(t *testing.T, payload string) (*DataDockBuilder, *recordingClient) {
+	t.Helper()
+	var data any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		t.Fatalf("Invalid test payload: %v", err)
+	}
+	client := newRecordingClient(data)
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+	return org.Harbor("test-harbor").DataDock("test-datadock"), client
+}
+
+func TestDataDockBuilder_Catalogs(t *testing.T) {
+	dataDock, client := newCatalogTestDataDock(t, testCatalogPayload)
+
+	catalogs, err := dataDock.Catalogs(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.last().endpoint != "https://test.example.com/data-docks/test-datadock/catalog" {
+		t.Errorf("Unexpected endpoint: %s", client.last().endpoint)
+	}
+	if len(catalogs) != 1 || catalogs[0].Name != "sales" || len(catalogs[0].Schemas) != 2 {
+		t.Fatalf("Unexpected catalogs: %+v", catalogs)
+	}
+	orders, _ := catalogs[0].Schemas[0].Table("orders")
+	if orders == nil || orders.Columns[0].DataType != "bigint" || orders.Columns[0].Nullable {
+		t.Errorf("Unexpected orders table: %+v", orders)
+	}
+}
+
+func TestCatalogBuilder_ListSchemasAndTables(t *testing.T) {
+	dataDock, _ := newCatalogTestDataDock(t, testCatalogPayload)
+	ctx := context.Background()
+
+	schemas, err := dataDock.Catalog("sales").ListSchemas(ctx)
+	if err != nil || !slices.Equal(schemas, []string{"public", "archive"}) {
+		t.Errorf("Unexpected schemas %v (%v)", schemas, err)
+	}
+
+	tables, err := dataDock.Catalog("sales").Schema("public").ListTables(ctx)
+	if err != nil || !slices.Equal(tables, []string{"orders", "order_lines"}) {
+		t.Errorf("Unexpected tables %v (%v)", tables, err)
+	}
+
+	if _, err := dataDock.Catalog("finance").ListSchemas(ctx); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown catalog, got %v", err)
+	}
+	if _, err := dataDock.Catalog("sales").Schema("private").ListTables(ctx); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown schema, got %v", err)
+	}
+}
+
+func TestCatalogBuilder_MalformedPayload(t *testing.T) {
+	dataDock, _ := newCatalogTestDataDock(t, `{"catalogs": [{"catalog_name": "sales", "schemas": {"public": {}}}]}`)
+
+	if _, err := dataDock.Catalog("sales").ListSchemas(context.Background()); !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Errorf("Expected ErrInvalidResponse instead of an empty list, got %v", err)
+	}
+}
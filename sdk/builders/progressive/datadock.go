@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
 
@@ -13,12 +15,17 @@ import (
 // Available methods:
 //   - Catalog(name) - Navigate to a specific catalog
 //   - GetCatalog(ctx) - Get the full catalog metadata
-//   - RefreshCatalog(ctx) - Trigger catalog introspection
+//   - Catalogs(ctx) - Get typed catalog metadata
+//   - Snapshot(ctx) - Capture the catalog metadata for later diffing
+//   - RefreshCatalog(ctx) - Trigger catalog introspection, tracked by an Operation
 //   - WakeUp(ctx) - Bring datadock online
+//   - WakeUpAndWait(ctx, opts) - Bring datadock online and wait until it is running
+//   - WaitFor(ctx, state, opts) - Poll until the datadock reaches a state
 //   - Sleep(ctx) - Put datadock to sleep
 //   - Get(ctx) - Get datadock details
-//   - Update(ctx, config) - Update datadock configuration
+//   - Update(ctx, req) - Update datadock configuration
 //   - Delete(ctx) - Delete this datadock
+//   - Batch() - Group writes across tables into one atomic batch
 type DataDockBuilder struct {
 	client     builders.ClientInterface
 	orgID      string
@@ -36,22 +43,59 @@ func (d *DataDockBuilder) Catalog(catalogName string) *CatalogBuilder {
 	}
 }
 
-// GetCatalog retrieves the full catalog metadata (schemas, tables, columns).
+// GetCatalog retrieves the full catalog metadata (schemas, tables, columns)
+// as a raw response. Use Catalogs for typed metadata.
 func (d *DataDockBuilder) GetCatalog(ctx context.Context) (*utils.Response, error) {
-	endpoint := fmt.Sprintf("%s/data-docks/%s/catalog",
-		d.client.GetConfig().BaseURL,
-		url.PathEscape(d.dataDockID),
-	)
-	return d.client.Do(ctx, "GET", endpoint, nil)
+	return d.client.Do(ctx, "GET", builders.CatalogEndpoint(d.client, d.dataDockID), nil)
+}
+
+// Snapshot captures the current catalog metadata of this datadock. Store it
+// with WriteJSON and compare it to a later one with builders.DiffSnapshots.
+//
+//	before, _ := datadock.Snapshot(ctx)
+//	datadock.RefreshCatalog(ctx)
+//	after, _ := datadock.Snapshot(ctx)
+//	if err := builders.DiffSnapshots(before, after).Err(); err != nil {
+//	    log.Fatal(err) // errors.Is(err, utils.ErrSchemaDrift)
+//	}
+func (d *DataDockBuilder) Snapshot(ctx context.Context) (*builders.CatalogSnapshot, error) {
+	return builders.TakeSnapshot(ctx, d.client, d.dataDockID)
+}
+
+// Catalogs retrieves the typed metadata of every catalog in this datadock.
+// A malformed payload returns utils.ErrInvalidResponse.
+func (d *DataDockBuilder) Catalogs(ctx context.Context) ([]Catalog, error) {
+	return builders.FetchCatalogs(ctx, d.client, d.dataDockID)
 }
 
 // RefreshCatalog triggers catalog introspection and updates metadata.
-func (d *DataDockBuilder) RefreshCatalog(ctx context.Context) (*utils.Response, error) {
+// The returned Operation tracks the refresh until the datadock reports it
+// done. The client's cached catalog for this datadock is dropped when the
+// refresh starts and again when it completes.
+//
+//	op, err := datadock.RefreshCatalog(ctx)
+//	if err == nil {
+//	    _, err = op.Wait(ctx, progressive.WaitOptions{Timeout: 5 * time.Minute})
+//	}
+func (d *DataDockBuilder) RefreshCatalog(ctx context.Context) (*Operation, error) {
 	endpoint := fmt.Sprintf("%s/data-docks/%s/catalog/refresh",
 		d.client.GetConfig().BaseURL,
 		url.PathEscape(d.dataDockID),
 	)
-	return d.client.Do(ctx, "POST", endpoint, nil)
+	sent := time.Now()
+	resp, err := d.client.Do(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	builders.InvalidateCatalogs(d.client, d.dataDockID)
+
+	op := newOperation(d.client, resp, sent)
+	op.results = []RefreshResult{{DataDockID: d.dataDockID}}
+	op.listed = true
+	return op, nil
 }
 
 // WakeUp brings the datadock online (for TrinoInternal/MinioInternal).
@@ -73,22 +117,35 @@ func (d *DataDockBuilder) Sleep(ctx context.Context) (*utils.Response, error) {
 }
 
 // Get retrieves datadock details.
-func (d *DataDockBuilder) Get(ctx context.Context) (*utils.Response, error) {
+func (d *DataDockBuilder) Get(ctx context.Context) (*DataDock, error) {
 	endpoint := fmt.Sprintf("%s/data-docks/%s",
 		d.client.GetConfig().BaseURL,
 		url.PathEscape(d.dataDockID),
 	)
-	return d.client.Do(ctx, "GET", endpoint, nil)
+	resp, err := d.client.Do(ctx, "GET", endpoint, nil)
+	var dataDock DataDock
+	if err := decodeResource(resp, err, &dataDock); err != nil {
+		return nil, err
+	}
+	return &dataDock, nil
 }
 
-// Update modifies datadock configuration.
-func (d *DataDockBuilder) Update(ctx context.Context, config map[string]interface{}) (*utils.Response, error) {
+// Update modifies datadock configuration and returns the updated datadock.
+func (d *DataDockBuilder) Update(ctx context.Context, req UpdateDataDockRequest) (*DataDock, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := fmt.Sprintf("%s/data-docks/%s",
 		d.client.GetConfig().BaseURL,
 		url.PathEscape(d.dataDockID),
 	)
-	body := utils.JsonMarshal(config)
-	return d.client.Do(ctx, "PATCH", endpoint, body)
+	body := utils.JsonMarshal(req)
+	resp, err := d.client.Do(ctx, "PATCH", endpoint, body)
+	var dataDock DataDock
+	if err := decodeResource(resp, err, &dataDock); err != nil {
+		return nil, err
+	}
+	return &dataDock, nil
 }
 
 // Delete removes this datadock.
@@ -111,3 +168,12 @@ func (d *DataDockBuilder) Search(query string) *SearchBuilder {
 		limitVal:       20, // Default limit
 	}
 }
+
+// Batch starts a write batch on this datadock. Operations take the table's
+// fluent builder (TableQueryBuilder.Query()).
+//
+//	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+//	result, err := dataDock.Batch().Insert(orders.Query(), order, "id").Commit(ctx)
+func (d *DataDockBuilder) Batch() *fluent.WriteBatch {
+	return fluent.NewQueryBuilder(d.client).DataDock(d.dataDockID).Batch()
+}
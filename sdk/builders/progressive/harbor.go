@@ -3,6 +3,7 @@ package progressive
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
@@ -12,8 +13,11 @@ import (
 // HarborBuilder represents a harbor context.
 // Available methods:
 //   - DataDock(id) - Navigate to a specific datadock
-//   - ListDataDocks(ctx) - List all datadocks in this harbor
-//   - CreateDataDock(ctx, config) - Create a new datadock
+//   - ListDataDocks(ctx, opts...) - List datadocks in this harbor
+//   - AllDataDocks(ctx, opts) - Iterate over all datadocks, page by page
+//   - CreateDataDock(ctx, req) - Create a new datadock
+//   - Get(ctx) - Get harbor details
+//   - Update(ctx, req) - Rename or update this harbor
 //   - Delete(ctx) - Delete this harbor
 type HarborBuilder struct {
 	client   builders.ClientInterface
@@ -31,23 +35,87 @@ func (h *HarborBuilder) DataDock(dataDockID string) *DataDockBuilder {
 	}
 }
 
-// ListDataDocks retrieves all datadocks in this harbor.
-func (h *HarborBuilder) ListDataDocks(ctx context.Context) (*utils.Response, error) {
-	endpoint := fmt.Sprintf("%s/harbors/%s/data-docks",
+// ListDataDocks retrieves one page of the datadocks in this harbor.
+func (h *HarborBuilder) ListDataDocks(ctx context.Context, opts ...ListOptions) ([]DataDock, error) {
+	return list[DataDock](ctx, h.dataDocksRequest(), opts)
+}
+
+// AllDataDocks iterates over the datadocks in this harbor, fetching pages as needed.
+func (h *HarborBuilder) AllDataDocks(ctx context.Context, opts ListOptions) iter.Seq2[DataDock, error] {
+	return listAll[DataDock](ctx, h.dataDocksRequest(), opts)
+}
+
+func (h *HarborBuilder) dataDocksRequest() listRequest {
+	return listRequest{
+		client: h.client,
+		endpoint: fmt.Sprintf("%s/harbors/%s/data-docks",
+			h.client.GetConfig().BaseURL,
+			url.PathEscape(h.harborID),
+		),
+		key:          "data_docks",
+		statusFilter: true,
+	}
+}
+
+// CreateDataDock creates a new datadock in this harbor.
+//
+//	dock, err := harbor.CreateDataDock(ctx, progressive.CreateDataDockRequest{
+//	    Name: "postgres-prod",
+//	    Connection: progressive.ConnectionKind{Type: "Trino", Config: map[string]any{
+//	        "host": "postgres.example.com",
+//	        "port": 5432,
+//	    }},
+//	})
+func (h *HarborBuilder) CreateDataDock(ctx context.Context, req CreateDataDockRequest) (*DataDock, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/data-docks", h.client.GetConfig().BaseURL)
+	body := utils.JsonMarshal(struct {
+		CreateDataDockRequest
+		HarborID string `json:"harbor_id"`
+	}{req, h.harborID})
+
+	resp, err := h.client.Do(ctx, "POST", endpoint, body)
+	var dataDock DataDock
+	if err := decodeResource(resp, err, &dataDock); err != nil {
+		return nil, err
+	}
+	return &dataDock, nil
+}
+
+// Get retrieves harbor details.
+func (h *HarborBuilder) Get(ctx context.Context) (*Harbor, error) {
+	endpoint := fmt.Sprintf("%s/harbors/%s",
 		h.client.GetConfig().BaseURL,
 		url.PathEscape(h.harborID),
 	)
-	return h.client.Do(ctx, "GET", endpoint, nil)
+	resp, err := h.client.Do(ctx, "GET", endpoint, nil)
+	var harbor Harbor
+	if err := decodeResource(resp, err, &harbor); err != nil {
+		return nil, err
+	}
+	return &harbor, nil
 }
 
-// CreateDataDock creates a new datadock in this harbor.
-func (h *HarborBuilder) CreateDataDock(ctx context.Context, config map[string]interface{}) (*utils.Response, error) {
-	// Ensure harbor_id is set
-	config["harbor_id"] = h.harborID
-
-	endpoint := fmt.Sprintf("%s/data-docks", h.client.GetConfig().BaseURL)
-	body := utils.JsonMarshal(config)
-	return h.client.Do(ctx, "POST", endpoint, body)
+// Update modifies this harbor and returns the updated harbor.
+//
+//	name := "analytics"
+//	harbor, err := client.Org(orgID).Harbor(harborID).Update(ctx, progressive.UpdateHarborRequest{Name: &name})
+func (h *HarborBuilder) Update(ctx context.Context, req UpdateHarborRequest) (*Harbor, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/harbors/%s",
+		h.client.GetConfig().BaseURL,
+		url.PathEscape(h.harborID),
+	)
+	resp, err := h.client.Do(ctx, "PATCH", endpoint, utils.JsonMarshal(req))
+	var harbor Harbor
+	if err := decodeResource(resp, err, &harbor); err != nil {
+		return nil, err
+	}
+	return &harbor, nil
 }
 
 // Delete removes this harbor.
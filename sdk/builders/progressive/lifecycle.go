@@ -0,0 +1,165 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Default values for WaitOptions.
+const (
+	DefaultWaitInterval    = time.Second
+	DefaultWaitMaxInterval = 15 * time.Second
+)
+
+// WaitOptions controls how WaitFor polls a datadock.
+type WaitOptions struct {
+	Interval    time.Duration // First delay between polls, doubled after each poll (default DefaultWaitInterval)
+	MaxInterval time.Duration // Upper bound of the delay (default DefaultWaitMaxInterval)
+	Timeout     time.Duration // Give up after this long (default: only when ctx is done)
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = DefaultWaitInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = DefaultWaitMaxInterval
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	return o
+}
+
+// WaitState is a datadock state WaitFor can wait for: a DataDockStatus
+// (e.g. DataDockRunning, DataDockSleeping) or CatalogRefreshedSince.
+type WaitState interface {
+	reached(dataDock *DataDock) bool
+	String() string
+}
+
+func (s DataDockStatus) reached(dataDock *DataDock) bool {
+	return dataDock.Status == s
+}
+
+func (s DataDockStatus) String() string {
+	return string(s)
+}
+
+type catalogRefreshed struct {
+	since time.Time
+}
+
+// CatalogRefreshedSince waits until the datadock reports a catalog refresh
+// after the given time.
+//
+//	start := time.Now()
+//	datadock.RefreshCatalog(ctx)
+//	datadock.WaitFor(ctx, progressive.CatalogRefreshedSince(start), progressive.WaitOptions{})
+func CatalogRefreshedSince(since time.Time) WaitState {
+	return catalogRefreshed{since: since}
+}
+
+func (c catalogRefreshed) reached(dataDock *DataDock) bool {
+	return dataDock.CatalogRefreshedAt != nil && dataDock.CatalogRefreshedAt.After(c.since)
+}
+
+func (c catalogRefreshed) String() string {
+	return "catalog refreshed since " + c.since.Format(time.RFC3339)
+}
+
+// DataDockStateError is returned when a datadock reaches a failure status
+// while waiting for another state. It wraps utils.ErrDataDockFailed.
+type DataDockStateError struct {
+	DataDockID string
+	Status     DataDockStatus
+	Message    string // StatusMessage reported by the datadock
+	Awaited    string // State that was waited for
+}
+
+func (e *DataDockStateError) Error() string {
+	message := fmt.Sprintf("%s: datadock %s is %s while waiting for %s", utils.ErrDataDockFailed, e.DataDockID, e.Status, e.Awaited)
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	return message
+}
+
+func (e *DataDockStateError) Unwrap() error {
+	return utils.ErrDataDockFailed
+}
+
+// WaitFor polls the datadock with exponential backoff until it reaches state
+// and returns its details. It fails with a *DataDockStateError if the
+// datadock reports a failure status, and with the context's error when ctx
+// is done or opts.Timeout expires.
+//
+//	dock, err := datadock.WaitFor(ctx, progressive.DataDockSleeping, progressive.WaitOptions{Timeout: time.Minute})
+func (d *DataDockBuilder) WaitFor(ctx context.Context, state WaitState, opts WaitOptions) (*DataDock, error) {
+	opts = opts.withDefaults()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	delay := opts.Interval
+	var lastStatus DataDockStatus
+	for {
+		dataDock, err := d.Get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, d.waitTimeout(ctx, state, lastStatus)
+			}
+			return nil, err
+		}
+		if state.reached(dataDock) {
+			return dataDock, nil
+		}
+		if dataDock.Status.Failed() {
+			return dataDock, &DataDockStateError{
+				DataDockID: d.dataDockID,
+				Status:     dataDock.Status,
+				Message:    dataDock.StatusMessage,
+				Awaited:    state.String(),
+			}
+		}
+		lastStatus = dataDock.Status
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, d.waitTimeout(ctx, state, lastStatus)
+		}
+		delay = min(delay*2, opts.MaxInterval)
+	}
+}
+
+func (d *DataDockBuilder) waitTimeout(ctx context.Context, state WaitState, lastStatus DataDockStatus) error {
+	return fmt.Errorf("datadock %s did not reach %s (last status %q): %w", d.dataDockID, state, lastStatus, ctx.Err())
+}
+
+// WakeUpAndWait wakes the datadock up and waits until it is running.
+// opts.Timeout covers both the wake-up request and the wait.
+//
+//	dock, err := datadock.WakeUpAndWait(ctx, progressive.WaitOptions{Timeout: 2 * time.Minute})
+func (d *DataDockBuilder) WakeUpAndWait(ctx context.Context, opts WaitOptions) (*DataDock, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		opts.Timeout = 0
+	}
+
+	resp, err := d.WakeUp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	return d.WaitFor(ctx, DataDockRunning, opts)
+}
@@ -0,0 +1,138 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// lifecycleClient answers datadock GETs with the next status of a script,
+// repeating the last one, and records every request.
+type lifecycleClient struct {
+	mu       sync.Mutex
+	statuses []map[string]any
+	requests []string
+}
+
+func (c *lifecycleClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, method+" "+strings.TrimPrefix(endpoint, "https://test.example.com"))
+	if method != "GET" {
+		return &utils.Response{Status: utils.StatusOK, HTTPCode: 202}, nil
+	}
+	data := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (c *lifecycleClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func newLifecycleDataDock(statuses ...map[string]any) (*DataDockBuilder, *lifecycleClient) {
+	client := &lifecycleClient{statuses: statuses}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+	return org.Harbor("test-harbor").DataDock("dd-1"), client
+}
+
+func dockStatus(value string) map[string]any {
+	return map[string]any{"id": "dd-1", "status": value}
+}
+
+var fastWait = WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+func TestWakeUpAndWait(t *testing.T) {
+	dataDock, client := newLifecycleDataDock(dockStatus("sleeping"), dockStatus("starting"), dockStatus("starting"), dockStatus("running"))
+
+	dock, err := dataDock.WakeUpAndWait(context.Background(), fastWait)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dock.Status != DataDockRunning {
+		t.Errorf("Expected a running datadock, got %q", dock.Status)
+	}
+	want := []string{"POST /data-docks/dd-1/wake-up", "GET /data-docks/dd-1", "GET /data-docks/dd-1", "GET /data-docks/dd-1", "GET /data-docks/dd-1"}
+	if strings.Join(client.requests, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected requests %v", client.requests)
+	}
+}
+
+func TestWaitFor_FailureStatus(t *testing.T) {
+	failed := dockStatus("failed")
+	failed["status_message"] = "out of memory"
+	dataDock, _ := newLifecycleDataDock(dockStatus("starting"), failed)
+
+	_, err := dataDock.WaitFor(context.Background(), DataDockRunning, fastWait)
+	var stateErr *DataDockStateError
+	if !errors.As(err, &stateErr) || !errors.Is(err, utils.ErrDataDockFailed) {
+		t.Fatalf("Expected a DataDockStateError, got %v", err)
+	}
+	if stateErr.Status != DataDockFailed || stateErr.Message != "out of memory" || stateErr.Awaited != "running" {
+		t.Errorf("Unexpected error %+v", stateErr)
+	}
+
+	// Waiting for the failure status itself succeeds
+	dataDock, _ = newLifecycleDataDock(failed)
+	if _, err := dataDock.WaitFor(context.Background(), DataDockFailed, fastWait); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestWaitFor_Timeout(t *testing.T) {
+	dataDock, client := newLifecycleDataDock(dockStatus("stopping"))
+
+	opts := fastWait
+	opts.Timeout = 20 * time.Millisecond
+	_, err := dataDock.WaitFor(context.Background(), DataDockSleeping, opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `last status "stopping"`) {
+		t.Errorf("Expected the last status in the error, got %v", err)
+	}
+	if len(client.requests) < 2 {
+		t.Errorf("Expected several polls, got %d", len(client.requests))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := dataDock.WaitFor(ctx, DataDockSleeping, fastWait); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+}
+
+func TestWaitFor_CatalogRefreshed(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := dockStatus("running")
+	before["catalog_refreshed_at"] = "2026-01-01T11:00:00Z"
+	after := dockStatus("running")
+	after["catalog_refreshed_at"] = "2026-01-01T12:00:05Z"
+	dataDock, client := newLifecycleDataDock(before, before, after)
+
+	dock, err := dataDock.WaitFor(context.Background(), CatalogRefreshedSince(start), fastWait)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !dock.CatalogRefreshedAt.After(start) || len(client.requests) != 3 {
+		t.Errorf("Unexpected result %v after %d requests", dock.CatalogRefreshedAt, len(client.requests))
+	}
+}
+
+func TestWaitOptions_Defaults(t *testing.T) {
+	opts := WaitOptions{}.withDefaults()
+	if opts.Interval != DefaultWaitInterval || opts.MaxInterval != DefaultWaitMaxInterval {
+		t.Errorf("Unexpected defaults %+v", opts)
+	}
+	opts = WaitOptions{Interval: time.Minute}.withDefaults()
+	if opts.MaxInterval != time.Minute {
+		t.Errorf("Expected MaxInterval to be raised to Interval, got %v", opts.MaxInterval)
+	}
+}
@@ -0,0 +1,214 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// DefaultPageSize is the page size used by the All* iterators when
+// ListOptions.PageSize is not set.
+const DefaultPageSize = 100
+
+// ListOptions filter, sort and paginate the management list methods.
+// The zero value lists everything the API returns in a single call.
+//
+//	docks, err := org.ListDataDocks(ctx, progressive.ListOptions{
+//	    NamePrefix: "postgres-",
+//	    Status:     progressive.DataDockRunning,
+//	    Sort:       "-created_at",
+//	})
+type ListOptions struct {
+	PageSize   int            // Maximum number of items per page (0 = API default)
+	PageToken  string         // Resume after a page, from the API's next_page_token
+	Offset     int            // Number of items to skip, for APIs without page tokens
+	NamePrefix string         // Only items whose name starts with this prefix
+	Status     DataDockStatus // Only datadocks in this status (datadock lists only)
+	Sort       string         // Sort field, prefixed with "-" for descending order, e.g. "-created_at"
+}
+
+// query validates the options and encodes them as query parameters.
+func (o ListOptions) query(statusFilter bool) (url.Values, error) {
+	switch {
+	case o.PageSize < 0:
+		return nil, fmt.Errorf("%w: page size must not be negative", utils.ErrInvalidRequest)
+	case o.Offset < 0:
+		return nil, fmt.Errorf("%w: offset must not be negative", utils.ErrInvalidRequest)
+	case o.Offset > 0 && o.PageToken != "":
+		return nil, fmt.Errorf("%w: offset and page token are mutually exclusive", utils.ErrInvalidRequest)
+	case o.Status != "" && !statusFilter:
+		return nil, fmt.Errorf("%w: status filter is only supported for datadocks", utils.ErrInvalidRequest)
+	}
+
+	params := url.Values{}
+	if o.PageSize > 0 {
+		params.Set("page_size", strconv.Itoa(o.PageSize))
+	}
+	if o.PageToken != "" {
+		params.Set("page_token", o.PageToken)
+	}
+	if o.Offset > 0 {
+		params.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.NamePrefix != "" {
+		params.Set("name_prefix", o.NamePrefix)
+	}
+	if o.Status != "" {
+		params.Set("status", string(o.Status))
+	}
+	if o.Sort != "" {
+		params.Set("sort", o.Sort)
+	}
+	return params, nil
+}
+
+// listRequest is a management list endpoint and the field its items are
+// returned under.
+type listRequest struct {
+	client       builders.ClientInterface
+	endpoint     string
+	key          string
+	statusFilter bool
+}
+
+// page is one page of a list response.
+type page[T any] struct {
+	items         []T
+	nextPageToken string
+	total         int // -1 when not reported
+}
+
+// optionsFrom returns the options passed to a variadic List method.
+func optionsFrom(opts []ListOptions) (ListOptions, error) {
+	switch len(opts) {
+	case 0:
+		return ListOptions{}, nil
+	case 1:
+		return opts[0], nil
+	}
+	return ListOptions{}, fmt.Errorf("%w: at most one ListOptions is accepted", utils.ErrInvalidRequest)
+}
+
+// listPage fetches one page of req.
+func listPage[T any](ctx context.Context, req listRequest, opts ListOptions) (*page[T], error) {
+	params, err := opts.query(req.statusFilter)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := req.endpoint
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	resp, err := req.client.Do(ctx, "GET", endpoint, nil)
+	return decodePage[T](resp, err, req.key)
+}
+
+// list fetches the single page of req selected by the variadic options.
+func list[T any](ctx context.Context, req listRequest, opts []ListOptions) ([]T, error) {
+	options, err := optionsFrom(opts)
+	if err != nil {
+		return nil, err
+	}
+	page, err := listPage[T](ctx, req, options)
+	if err != nil {
+		return nil, err
+	}
+	return page.items, nil
+}
+
+// listAll iterates over every item of req, fetching the following pages
+// on demand. Pages are followed with the API's next_page_token when it
+// returns one, and by offset otherwise until a page comes back short or
+// starts with the same item as the previous one, as it does when the API
+// ignores the offset. Iteration stops at the first error, which is yielded
+// with a zero item.
+func listAll[T any](ctx context.Context, req listRequest, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if opts.PageSize == 0 {
+			opts.PageSize = DefaultPageSize
+		}
+
+		seen := 0
+		var previous []T
+		for {
+			page, err := listPage[T](ctx, req, opts)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if opts.PageToken == "" && len(previous) > 0 && len(page.items) > 0 && reflect.DeepEqual(page.items[0], previous[0]) {
+				return
+			}
+			previous = page.items
+			for _, item := range page.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			seen += len(page.items)
+
+			switch {
+			case page.nextPageToken == opts.PageToken && page.nextPageToken != "":
+				yield(zero, fmt.Errorf("%w: the API returned the same page token twice", utils.ErrInvalidResponse))
+				return
+			case page.nextPageToken != "":
+				opts.PageToken, opts.Offset = page.nextPageToken, 0
+			case opts.PageToken == "" && len(page.items) == opts.PageSize && (page.total < 0 || seen < page.total):
+				opts.Offset += len(page.items)
+			default:
+				return
+			}
+		}
+	}
+}
+
+// decodePage checks resp and decodes a list response. The list may be the
+// whole payload or wrapped in an object under key, "items" or "data",
+// alongside optional next_page_token and total fields.
+func decodePage[T any](resp *utils.Response, err error, key string) (*page[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+
+	result := &page[T]{items: []T{}, total: -1}
+	data := resp.Data
+	if object, ok := data.(map[string]any); ok {
+		data = nil
+		for _, field := range []string{key, "items", "data"} {
+			if list, ok := object[field].([]any); ok {
+				data = list
+				break
+			}
+		}
+		if data == nil {
+			return nil, fmt.Errorf("%w: list response has no %q field", utils.ErrInvalidResponse, key)
+		}
+		if token, ok := object["next_page_token"].(string); ok {
+			result.nextPageToken = token
+		}
+		if total, ok := object["total"].(float64); ok {
+			result.total = int(total)
+		}
+	}
+
+	if data == nil {
+		return result, nil
+	}
+	if _, ok := data.([]any); !ok {
+		return nil, fmt.Errorf("%w: expected a list, got %T", utils.ErrInvalidResponse, data)
+	}
+	if err := utils.UnmarshalData(data, &result.items); err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidResponse, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// pagingClient serves a list of harbors page by page, either with page
+// tokens or by offset, or all at once when it ignores paging.
+type pagingClient struct {
+	harbors       int
+	tokens        bool
+	ignoresPaging bool
+	requests      []url.Values
+}
+
+func (c *pagingClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	params := u.Query()
+	c.requests = append(c.requests, params)
+
+	size, _ := strconv.Atoi(params.Get("page_size"))
+	start, _ := strconv.Atoi(params.Get("offset"))
+	if token := params.Get("page_token"); token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := min(start+size, c.harbors)
+	if c.ignoresPaging {
+		start, end = 0, c.harbors
+	}
+
+	var items []any
+	for i := start; i < end; i++ {
+		items = append(items, map[string]any{"id": fmt.Sprintf("h-%d", i)})
+	}
+	data := map[string]any{"harbors": items}
+	if c.tokens && end < c.harbors {
+		data["next_page_token"] = strconv.Itoa(end)
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (c *pagingClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func collectHarbors(t *testing.T, org *OrgBuilder, opts ListOptions) []Harbor {
+	t.Helper()
+	var harbors []Harbor
+	for harbor, err := range org.AllHarbors(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		harbors = append(harbors, harbor)
+	}
+	return harbors
+}
+
+func TestListOptions_Query(t *testing.T) {
+	org, client := newResourceTestOrg(t, `[]`)
+
+	_, err := org.ListDataDocks(context.Background(), ListOptions{
+		PageSize:   20,
+		Offset:     40,
+		NamePrefix: "postgres-",
+		Status:     DataDockRunning,
+		Sort:       "-created_at",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := "https://test.example.com/test-org/data-docks?name_prefix=postgres-&offset=40&page_size=20&sort=-created_at&status=running"
+	if client.last().endpoint != expected {
+		t.Errorf("Expected %s, got %s", expected, client.last().endpoint)
+	}
+
+	if _, err := org.ListHarbors(context.Background()); err != nil || client.last().endpoint != "https://test.example.com/test-org/harbors" {
+		t.Errorf("Expected no query without options, got %s (%v)", client.last().endpoint, err)
+	}
+}
+
+func TestListOptions_Invalid(t *testing.T) {
+	org, client := newResourceTestOrg(t, `[]`)
+	ctx := context.Background()
+
+	invalid := map[string]error{
+		"negative page size": func() error { _, err := org.ListHarbors(ctx, ListOptions{PageSize: -1}); return err }(),
+		"token and offset":   func() error { _, err := org.ListHarbors(ctx, ListOptions{PageToken: "t", Offset: 10}); return err }(),
+		"status on harbors":  func() error { _, err := org.ListHarbors(ctx, ListOptions{Status: DataDockRunning}); return err }(),
+		"status on members":  func() error { _, err := org.ListMembers(ctx, ListOptions{Status: DataDockRunning}); return err }(),
+		"two options":        func() error { _, err := org.ListHarbors(ctx, ListOptions{}, ListOptions{}); return err }(),
+	}
+	for name, err := range invalid {
+		if !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %d", len(client.requests))
+	}
+}
+
+func TestAllHarbors_PageTokens(t *testing.T) {
+	client := &pagingClient{harbors: 5, tokens: true}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+
+	harbors := collectHarbors(t, org, ListOptions{PageSize: 2, NamePrefix: "h-"})
+	if len(harbors) != 5 || harbors[4].ID != "h-4" {
+		t.Errorf("Expected 5 harbors, got %+v", harbors)
+	}
+	if len(client.requests) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(client.requests))
+	}
+	if client.requests[2].Get("page_token") != "4" || client.requests[2].Get("name_prefix") != "h-" {
+		t.Errorf("Expected the token and filters on the last page, got %v", client.requests[2])
+	}
+}
+
+func TestAllHarbors_Offsets(t *testing.T) {
+	client := &pagingClient{harbors: 4}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+
+	harbors := collectHarbors(t, org, ListOptions{PageSize: 2})
+	if len(harbors) != 4 {
+		t.Errorf("Expected 4 harbors, got %+v", harbors)
+	}
+	// The last page is empty since the API cannot tell there is nothing left
+	if len(client.requests) != 3 || client.requests[2].Get("offset") != "4" {
+		t.Errorf("Unexpected requests %v", client.requests)
+	}
+
+	client.requests = nil
+	if harbors := collectHarbors(t, org, ListOptions{}); len(harbors) != 4 || len(client.requests) != 1 {
+		t.Errorf("Expected one page of DefaultPageSize, got %d harbors in %d requests", len(harbors), len(client.requests))
+	}
+	if client.requests[0].Get("page_size") != strconv.Itoa(DefaultPageSize) {
+		t.Errorf("Expected the default page size, got %v", client.requests[0])
+	}
+}
+
+func TestAllHarbors_IgnoredOffset(t *testing.T) {
+	// Exactly a page of harbors, without total or next_page_token
+	client := &pagingClient{harbors: DefaultPageSize, ignoresPaging: true}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+
+	harbors := collectHarbors(t, org, ListOptions{})
+	if len(harbors) != DefaultPageSize {
+		t.Errorf("Expected %d harbors without repeats, got %d", DefaultPageSize, len(harbors))
+	}
+	if len(client.requests) != 2 {
+		t.Errorf("Expected to stop on the repeated page, got %d requests", len(client.requests))
+	}
+}
+
+func TestAllHarbors_Break(t *testing.T) {
+	client := &pagingClient{harbors: 10, tokens: true}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+
+	for harbor, err := range org.AllHarbors(context.Background(), ListOptions{PageSize: 3}) {
+		if err != nil || harbor.ID == "h-1" {
+			break
+		}
+	}
+	if len(client.requests) != 1 {
+		t.Errorf("Expected no more pages after break, got %d requests", len(client.requests))
+	}
+}
+
+func TestAllDataDocks_Error(t *testing.T) {
+	org, _ := newResourceTestOrg(t, `{"unexpected": true}`)
+
+	var errs int
+	for _, err := range org.Harbor("test-harbor").AllDataDocks(context.Background(), ListOptions{}) {
+		if !errors.Is(err, utils.ErrInvalidResponse) {
+			t.Errorf("Expected ErrInvalidResponse, got %v", err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("Expected a single error, got %d", errs)
+	}
+}
@@ -0,0 +1,188 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// RefreshResult is the progress of a catalog refresh on one datadock.
+type RefreshResult struct {
+	DataDockID  string
+	Done        bool       // The catalog was refreshed, or the refresh failed (Err)
+	RefreshedAt *time.Time // When the catalog was refreshed, once Done
+	Err         error      // Why the refresh failed, e.g. a *DataDockStateError
+}
+
+// Operation tracks a catalog refresh started by RefreshCatalog or
+// RefreshAllDataDocks. The refresh is followed by polling each datadock
+// until it reports a catalog refresh (catalog_refreshed_at) later than the
+// start of the operation. It is safe for concurrent use.
+type Operation struct {
+	Response  *utils.Response // Answer to the request that started the refresh
+	StartedAt time.Time       // When that request was sent, in server time if the answer has a Date header
+
+	client builders.ClientInterface
+	orgID  string // Set for an org-wide refresh, whose datadocks are listed lazily
+
+	mu      sync.Mutex
+	listed  bool
+	results []RefreshResult
+}
+
+// newOperation tracks a refresh whose request was sent at sent (local time).
+// A synchronous API refreshes the catalog before answering, so the operation
+// starts when the request was sent, not answered. With a Date header, that
+// is the server time of the answer minus the round trip and the header's
+// one second resolution, which keeps the comparison on the server's clock.
+func newOperation(client builders.ClientInterface, resp *utils.Response, sent time.Time) *Operation {
+	started := sent
+	if resp.Header != nil {
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			started = date.Add(-time.Since(sent) - time.Second)
+		}
+	}
+	return &Operation{Response: resp, StartedAt: started, client: client}
+}
+
+// Results returns the last known progress of each datadock, without polling.
+// It is empty for an org-wide refresh until Status or Wait listed the datadocks.
+func (o *Operation) Results() []RefreshResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]RefreshResult(nil), o.results...)
+}
+
+// Done reports whether every datadock finished refreshing, successfully or not.
+func (o *Operation) Done() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.listed {
+		return false
+	}
+	for _, result := range o.results {
+		if !result.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the failed refreshes joined in one error, or nil.
+func (o *Operation) Err() error {
+	var errs []error
+	for _, result := range o.Results() {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("datadock %s: %w", result.DataDockID, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Status polls the datadocks that are still refreshing once and returns the
+// progress of each datadock.
+func (o *Operation) Status(ctx context.Context) ([]RefreshResult, error) {
+	if err := o.listDataDocks(ctx); err != nil {
+		return nil, err
+	}
+
+	for i, result := range o.Results() {
+		if result.Done {
+			continue
+		}
+		dataDock, err := (&DataDockBuilder{client: o.client, dataDockID: result.DataDockID}).Get(ctx)
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			result.Done, result.Err = true, err
+		case err != nil:
+			return nil, err
+		case dataDock.Status.Failed():
+			result.Done = true
+			result.Err = &DataDockStateError{
+				DataDockID: result.DataDockID,
+				Status:     dataDock.Status,
+				Message:    dataDock.StatusMessage,
+				Awaited:    "catalog refresh",
+			}
+		case CatalogRefreshedSince(o.StartedAt).reached(dataDock):
+			result.Done, result.RefreshedAt = true, dataDock.CatalogRefreshedAt
+			builders.InvalidateCatalogs(o.client, result.DataDockID)
+		}
+
+		o.mu.Lock()
+		o.results[i] = result
+		o.mu.Unlock()
+	}
+	return o.Results(), nil
+}
+
+// Wait polls with backoff until every datadock finished refreshing, then
+// returns the results and Err. It returns the context's error when ctx is
+// done or opts.Timeout expires.
+//
+//	op, err := datadock.RefreshCatalog(ctx)
+//	results, err := op.Wait(ctx, progressive.WaitOptions{Timeout: 5 * time.Minute})
+func (o *Operation) Wait(ctx context.Context, opts WaitOptions) ([]RefreshResult, error) {
+	opts = opts.withDefaults()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	delay := opts.Interval
+	for {
+		results, err := o.Status(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return o.Results(), fmt.Errorf("catalog refresh not finished: %w", ctx.Err())
+			}
+			return o.Results(), err
+		}
+		if o.Done() {
+			return results, o.Err()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return o.Results(), fmt.Errorf("catalog refresh not finished: %w", ctx.Err())
+		}
+		delay = min(delay*2, opts.MaxInterval)
+	}
+}
+
+// listDataDocks resolves the datadocks of an org-wide refresh.
+func (o *Operation) listDataDocks(ctx context.Context) error {
+	o.mu.Lock()
+	listed := o.listed
+	o.mu.Unlock()
+	if listed {
+		return nil
+	}
+
+	var dataDocks []DataDock
+	for dataDock, err := range (&OrgBuilder{Client: o.client, OrgID: o.orgID}).AllDataDocks(ctx, ListOptions{}) {
+		if err != nil {
+			return err
+		}
+		dataDocks = append(dataDocks, dataDock)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.listed {
+		o.results = make([]RefreshResult, len(dataDocks))
+		for i, dataDock := range dataDocks {
+			o.results[i] = RefreshResult{DataDockID: dataDock.ID}
+		}
+		o.listed = true
+	}
+	return nil
+}
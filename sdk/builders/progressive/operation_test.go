@@ -0,0 +1,181 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// refreshClient fakes a catalog refresh, asynchronous (202) unless
+// refreshCode says otherwise: each datadock reports its scripted details in
+// turn, repeating the last one.
+type refreshClient struct {
+	mu          sync.Mutex
+	docks       map[string][]map[string]any
+	requests    []string
+	refreshCode int
+}
+
+const refreshDate = "Thu, 01 Jan 2026 12:00:00 GMT"
+
+func (c *refreshClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	path := strings.TrimPrefix(endpoint, "https://test.example.com")
+	c.requests = append(c.requests, method+" "+path)
+	path, _, _ = strings.Cut(path, "?")
+
+	switch {
+	case method == "POST":
+		code := c.refreshCode
+		if code == 0 {
+			code = http.StatusAccepted
+		}
+		return &utils.Response{Status: utils.StatusOK, HTTPCode: code, Header: http.Header{"Date": {refreshDate}}}, nil
+	case path == "/test-org/data-docks":
+		var list []any
+		for _, id := range []string{"dd-1", "dd-2"} {
+			list = append(list, map[string]any{"id": id})
+		}
+		return &utils.Response{Status: utils.StatusOK, Data: list, HTTPCode: 200}, nil
+	}
+
+	id := strings.TrimPrefix(path, "/data-docks/")
+	script, ok := c.docks[id]
+	if !ok {
+		return &utils.Response{Status: utils.StatusError, HTTPCode: 404}, utils.ErrNotFound
+	}
+	if len(script) > 1 {
+		c.docks[id] = script[1:]
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: script[0], HTTPCode: 200}, nil
+}
+
+func (c *refreshClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func refreshedAt(id, at string) map[string]any {
+	return map[string]any{"id": id, "status": "running", "catalog_refreshed_at": at}
+}
+
+func TestRefreshCatalog_Operation(t *testing.T) {
+	client := &refreshClient{docks: map[string][]map[string]any{
+		"dd-1": {
+			refreshedAt("dd-1", "2026-01-01T11:00:00Z"),
+			refreshedAt("dd-1", "2026-01-01T11:00:00Z"),
+			refreshedAt("dd-1", "2026-01-01T12:00:03Z"),
+		},
+	}}
+	dataDock := (&OrgBuilder{Client: client, OrgID: "test-org"}).Harbor("h").DataDock("dd-1")
+
+	op, err := dataDock.RefreshCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The Date header minus the round trip and its resolution
+	date := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if lag := date.Sub(op.StartedAt); lag < time.Second || lag > 2*time.Second {
+		t.Errorf("Expected StartedAt from the Date header, got %v", op.StartedAt)
+	}
+
+	results, err := op.Status(context.Background())
+	if err != nil || len(results) != 1 || results[0].Done || op.Done() {
+		t.Fatalf("Expected a pending refresh, got %+v (%v)", results, err)
+	}
+
+	results, err = op.Wait(context.Background(), fastWait)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !results[0].Done || results[0].RefreshedAt == nil || !op.Done() {
+		t.Errorf("Expected a finished refresh, got %+v", results)
+	}
+	if len(client.requests) != 4 {
+		t.Errorf("Expected the refresh and 3 polls, got %v", client.requests)
+	}
+
+	// A finished operation does not poll again
+	if _, err := op.Status(context.Background()); err != nil || len(client.requests) != 4 {
+		t.Errorf("Expected no more requests, got %v (%v)", client.requests, err)
+	}
+}
+
+func TestRefreshCatalog_Synchronous(t *testing.T) {
+	// The catalog is refreshed before the answer, whose Date header is
+	// truncated to the second
+	client := &refreshClient{refreshCode: http.StatusOK, docks: map[string][]map[string]any{
+		"dd-1": {refreshedAt("dd-1", "2026-01-01T11:59:59.800Z")},
+	}}
+	dataDock := (&OrgBuilder{Client: client, OrgID: "test-org"}).Harbor("h").DataDock("dd-1")
+
+	op, err := dataDock.RefreshCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	opts := fastWait
+	opts.Timeout = time.Second
+	results, err := op.Wait(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !results[0].Done || len(client.requests) != 2 {
+		t.Errorf("Expected the refresh to be done on the first poll, got %+v after %v", results, client.requests)
+	}
+}
+
+func TestRefreshAllDataDocks_PerDataDockResults(t *testing.T) {
+	failed := map[string]any{"id": "dd-2", "status": "failed", "status_message": "connection refused"}
+	client := &refreshClient{docks: map[string][]map[string]any{
+		"dd-1": {refreshedAt("dd-1", "2026-01-01T12:00:01Z")},
+		"dd-2": {refreshedAt("dd-2", "2026-01-01T10:00:00Z"), failed},
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+
+	op, err := org.RefreshAllDataDocks(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(op.Results()) != 0 || op.Done() {
+		t.Errorf("Expected datadocks to be listed lazily, got %+v", op.Results())
+	}
+
+	results, err := op.Wait(context.Background(), fastWait)
+	if !errors.Is(err, utils.ErrDataDockFailed) || !strings.Contains(err.Error(), "dd-2") {
+		t.Fatalf("Expected the dd-2 failure, got %v", err)
+	}
+	if len(results) != 2 || !results[0].Done || results[0].Err != nil {
+		t.Errorf("Expected dd-1 to be refreshed, got %+v", results)
+	}
+	var stateErr *DataDockStateError
+	if !errors.As(results[1].Err, &stateErr) || stateErr.Message != "connection refused" {
+		t.Errorf("Expected a DataDockStateError for dd-2, got %v", results[1].Err)
+	}
+	if client.requests[1] != "GET /test-org/data-docks?page_size=100" {
+		t.Errorf("Expected the datadocks to be listed, got %v", client.requests)
+	}
+}
+
+func TestOperation_WaitTimeout(t *testing.T) {
+	client := &refreshClient{docks: map[string][]map[string]any{
+		"dd-1": {refreshedAt("dd-1", "2026-01-01T11:00:00Z")},
+	}}
+	dataDock := (&OrgBuilder{Client: client, OrgID: "test-org"}).Harbor("h").DataDock("dd-1")
+
+	op, _ := dataDock.RefreshCatalog(context.Background())
+	opts := fastWait
+	opts.Timeout = 10 * time.Millisecond
+	results, err := op.Wait(context.Background(), opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	if len(results) != 1 || results[0].Done {
+		t.Errorf("Expected a pending result, got %+v", results)
+	}
+}
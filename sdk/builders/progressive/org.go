@@ -3,7 +3,9 @@ package progressive
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
+	"time"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
@@ -12,9 +14,14 @@ import (
 // OrgBuilder represents an organization context.
 // Available methods:
 //   - Harbor(id) - Navigate to a specific harbor
-//   - ListHarbors(ctx) - List all harbors in this org
+//   - ListHarbors(ctx, opts...) - List harbors in this org
+//   - AllHarbors(ctx, opts) - Iterate over all harbors, page by page
 //   - CreateHarbor(ctx, name) - Create a new harbor
-//   - ListDataDocks(ctx) - List all datadocks across all harbors
+//   - ListDataDocks(ctx, opts...) - List datadocks across all harbors
+//   - AllDataDocks(ctx, opts) - Iterate over all datadocks, page by page
+//   - Get(ctx) - Get organization details and quotas
+//   - ListMembers(ctx, opts...) - List the organization's members
+//   - AllMembers(ctx, opts) - Iterate over all members, page by page
 type OrgBuilder struct {
 	Client builders.ClientInterface
 	OrgID  string
@@ -29,17 +36,95 @@ func (o *OrgBuilder) Harbor(harborID string) *HarborBuilder {
 	}
 }
 
-// ListHarbors retrieves all harbors in this organization.
-func (o *OrgBuilder) ListHarbors(ctx context.Context) (*utils.Response, error) {
-	endpoint := fmt.Sprintf("%s/%s/harbors",
+// ListOrgs retrieves the organizations visible to the client's credentials.
+// Without options the API's default page is returned; see AllOrgs to
+// iterate over every page.
+func ListOrgs(ctx context.Context, client builders.ClientInterface, opts ...ListOptions) ([]Org, error) {
+	return list[Org](ctx, orgsRequest(client), opts)
+}
+
+// AllOrgs iterates over the organizations visible to the client's
+// credentials, fetching pages as needed.
+func AllOrgs(ctx context.Context, client builders.ClientInterface, opts ListOptions) iter.Seq2[Org, error] {
+	return listAll[Org](ctx, orgsRequest(client), opts)
+}
+
+func orgsRequest(client builders.ClientInterface) listRequest {
+	return listRequest{
+		client:   client,
+		endpoint: fmt.Sprintf("%s/orgs", client.GetConfig().BaseURL),
+		key:      "orgs",
+	}
+}
+
+// Get retrieves the organization's details, including its quotas.
+func (o *OrgBuilder) Get(ctx context.Context) (*Org, error) {
+	endpoint := fmt.Sprintf("%s/%s",
 		o.Client.GetConfig().BaseURL,
 		url.PathEscape(o.OrgID),
 	)
-	return o.Client.Do(ctx, "GET", endpoint, nil)
+	resp, err := o.Client.Do(ctx, "GET", endpoint, nil)
+	var org Org
+	if err := decodeResource(resp, err, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// ListMembers retrieves one page of the members of this organization.
+func (o *OrgBuilder) ListMembers(ctx context.Context, opts ...ListOptions) ([]Member, error) {
+	return list[Member](ctx, o.membersRequest(), opts)
+}
+
+// AllMembers iterates over the members of this organization, fetching pages as needed.
+func (o *OrgBuilder) AllMembers(ctx context.Context, opts ListOptions) iter.Seq2[Member, error] {
+	return listAll[Member](ctx, o.membersRequest(), opts)
+}
+
+func (o *OrgBuilder) membersRequest() listRequest {
+	return listRequest{
+		client: o.Client,
+		endpoint: fmt.Sprintf("%s/%s/members",
+			o.Client.GetConfig().BaseURL,
+			url.PathEscape(o.OrgID),
+		),
+		key: "members",
+	}
+}
+
+// ListHarbors retrieves one page of the harbors in this organization.
+func (o *OrgBuilder) ListHarbors(ctx context.Context, opts ...ListOptions) ([]Harbor, error) {
+	return list[Harbor](ctx, o.harborsRequest(), opts)
+}
+
+// AllHarbors iterates over the harbors in this organization, fetching pages as needed.
+//
+//	for harbor, err := range org.AllHarbors(ctx, progressive.ListOptions{NamePrefix: "prod-"}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(harbor.Name)
+//	}
+func (o *OrgBuilder) AllHarbors(ctx context.Context, opts ListOptions) iter.Seq2[Harbor, error] {
+	return listAll[Harbor](ctx, o.harborsRequest(), opts)
+}
+
+func (o *OrgBuilder) harborsRequest() listRequest {
+	return listRequest{
+		client: o.Client,
+		endpoint: fmt.Sprintf("%s/%s/harbors",
+			o.Client.GetConfig().BaseURL,
+			url.PathEscape(o.OrgID),
+		),
+		key: "harbors",
+	}
 }
 
 // CreateHarbor creates a new harbor in this organization.
-func (o *OrgBuilder) CreateHarbor(ctx context.Context, name string) (*utils.Response, error) {
+func (o *OrgBuilder) CreateHarbor(ctx context.Context, name string) (*Harbor, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: harbor name is required", utils.ErrInvalidRequest)
+	}
 	endpoint := fmt.Sprintf("%s/%s/harbors",
 		o.Client.GetConfig().BaseURL,
 		url.PathEscape(o.OrgID),
@@ -47,23 +132,57 @@ func (o *OrgBuilder) CreateHarbor(ctx context.Context, name string) (*utils.Resp
 	body := utils.JsonMarshal(map[string]interface{}{
 		"name": name,
 	})
-	return o.Client.Do(ctx, "POST", endpoint, body)
+	resp, err := o.Client.Do(ctx, "POST", endpoint, body)
+	var harbor Harbor
+	if err := decodeResource(resp, err, &harbor); err != nil {
+		return nil, err
+	}
+	return &harbor, nil
 }
 
-// ListDataDocks retrieves all datadocks across all harbors in this organization.
-func (o *OrgBuilder) ListDataDocks(ctx context.Context) (*utils.Response, error) {
-	endpoint := fmt.Sprintf("%s/%s/data-docks",
-		o.Client.GetConfig().BaseURL,
-		url.PathEscape(o.OrgID),
-	)
-	return o.Client.Do(ctx, "GET", endpoint, nil)
+// ListDataDocks retrieves one page of the datadocks across all harbors in this organization.
+func (o *OrgBuilder) ListDataDocks(ctx context.Context, opts ...ListOptions) ([]DataDock, error) {
+	return list[DataDock](ctx, o.dataDocksRequest(), opts)
+}
+
+// AllDataDocks iterates over the datadocks across all harbors in this
+// organization, fetching pages as needed.
+func (o *OrgBuilder) AllDataDocks(ctx context.Context, opts ListOptions) iter.Seq2[DataDock, error] {
+	return listAll[DataDock](ctx, o.dataDocksRequest(), opts)
+}
+
+func (o *OrgBuilder) dataDocksRequest() listRequest {
+	return listRequest{
+		client: o.Client,
+		endpoint: fmt.Sprintf("%s/%s/data-docks",
+			o.Client.GetConfig().BaseURL,
+			url.PathEscape(o.OrgID),
+		),
+		key:          "data_docks",
+		statusFilter: true,
+	}
 }
 
 // RefreshAllDataDocks triggers a catalog refresh on all datadocks in this organization.
-func (o *OrgBuilder) RefreshAllDataDocks(ctx context.Context) (*utils.Response, error) {
+// The returned Operation reports per-datadock results; the datadocks are
+// listed on the first Status or Wait. The client's catalog cache is cleared
+// when the refresh starts, and per datadock when it completes.
+func (o *OrgBuilder) RefreshAllDataDocks(ctx context.Context) (*Operation, error) {
 	endpoint := fmt.Sprintf("%s/%s/data-docks/refresh",
 		o.Client.GetConfig().BaseURL,
 		url.PathEscape(o.OrgID),
 	)
-	return o.Client.Do(ctx, "POST", endpoint, nil)
+	sent := time.Now()
+	resp, err := o.Client.Do(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != utils.StatusOK {
+		return nil, fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	builders.InvalidateAllCatalogs(o.Client)
+
+	op := newOperation(o.Client, resp, sent)
+	op.orgID = o.OrgID
+	return op, nil
 }
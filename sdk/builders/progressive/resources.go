@@ -0,0 +1,205 @@
+package progressive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Org is an organization visible to the current token.
+type Org struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Quotas      OrgQuotas `json:"quotas"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrgQuotas are the resource limits of an organization. Zero means unlimited
+// or not reported.
+type OrgQuotas struct {
+	MaxHarbors   int   `json:"max_harbors,omitempty"`
+	MaxDataDocks int   `json:"max_data_docks,omitempty"`
+	MaxMembers   int   `json:"max_members,omitempty"`
+	StorageBytes int64 `json:"storage_bytes,omitempty"`
+}
+
+// Member is a user of an organization.
+type Member struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Harbor is a group of datadocks within an organization.
+type Harbor struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OrgID     string    `json:"org_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DataDockStatus is the lifecycle state reported by a datadock.
+type DataDockStatus string
+
+const (
+	DataDockRunning  DataDockStatus = "running"
+	DataDockStarting DataDockStatus = "starting"
+	DataDockSleeping DataDockStatus = "sleeping"
+	DataDockStopping DataDockStatus = "stopping"
+	DataDockFailed   DataDockStatus = "failed"
+	DataDockError    DataDockStatus = "error"
+)
+
+// Failed reports whether the status is a failure the datadock will not
+// leave on its own.
+func (s DataDockStatus) Failed() bool {
+	return s == DataDockFailed || s == DataDockError
+}
+
+// DataDock is a connection to a data source, hosted in a harbor.
+type DataDock struct {
+	ID                 string         `json:"id"`
+	Name               string         `json:"name"`
+	Description        string         `json:"description,omitempty"`
+	HarborID           string         `json:"harbor_id"`
+	Status             DataDockStatus `json:"status"`
+	StatusMessage      string         `json:"status_message,omitempty"` // Cause of a failure, if any
+	Connection         ConnectionKind `json:"connection_kind"`
+	CatalogRefreshedAt *time.Time     `json:"catalog_refreshed_at,omitempty"` // Last catalog introspection
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// ConnectionKind is the type of a datadock and its connection settings.
+// It is encoded as {"<Type>": {<Config>}}, or as "<Type>" for types
+// without settings (e.g. "TrinoInternal").
+type ConnectionKind struct {
+	Type   string
+	Config map[string]any
+}
+
+// MarshalJSON encodes the connection as {"<Type>": {<Config>}}.
+func (k ConnectionKind) MarshalJSON() ([]byte, error) {
+	if k.Type == "" {
+		return []byte("null"), nil
+	}
+	if k.Config == nil {
+		return json.Marshal(k.Type)
+	}
+	return json.Marshal(map[string]map[string]any{k.Type: k.Config})
+}
+
+// UnmarshalJSON accepts both the object and the bare string form.
+func (k *ConnectionKind) UnmarshalJSON(data []byte) error {
+	*k = ConnectionKind{}
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &k.Type)
+	}
+
+	var variants map[string]map[string]any
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return fmt.Errorf("connection_kind: %w", err)
+	}
+	if len(variants) != 1 {
+		return fmt.Errorf("connection_kind: expected a single connection type, got %d", len(variants))
+	}
+	for kind, config := range variants {
+		k.Type, k.Config = kind, config
+	}
+	return nil
+}
+
+// UpdateHarborRequest lists the harbor fields to change. Nil fields are
+// left untouched.
+type UpdateHarborRequest struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Validate checks that the request changes something and that the changed
+// fields are valid.
+func (r UpdateHarborRequest) Validate() error {
+	if r.Name == nil {
+		return fmt.Errorf("%w: harbor update has no fields to change", utils.ErrInvalidRequest)
+	}
+	if *r.Name == "" {
+		return fmt.Errorf("%w: harbor name cannot be empty", utils.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// CreateDataDockRequest describes a datadock to create in a harbor.
+type CreateDataDockRequest struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Connection  ConnectionKind `json:"connection_kind"`
+}
+
+// Validate checks the fields required by the API.
+func (r CreateDataDockRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: datadock name is required", utils.ErrInvalidRequest)
+	}
+	if r.Connection.Type == "" {
+		return fmt.Errorf("%w: datadock connection type is required", utils.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// UpdateDataDockRequest lists the datadock fields to change. Nil fields
+// are left untouched.
+type UpdateDataDockRequest struct {
+	Name        *string         `json:"name,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Connection  *ConnectionKind `json:"connection_kind,omitempty"`
+}
+
+// Validate checks that the request changes something and that the changed
+// fields are valid.
+func (r UpdateDataDockRequest) Validate() error {
+	if r.Name == nil && r.Description == nil && r.Connection == nil {
+		return fmt.Errorf("%w: datadock update has no fields to change", utils.ErrInvalidRequest)
+	}
+	if r.Name != nil && *r.Name == "" {
+		return fmt.Errorf("%w: datadock name cannot be empty", utils.ErrInvalidRequest)
+	}
+	if r.Connection != nil && r.Connection.Type == "" {
+		return fmt.Errorf("%w: datadock connection type cannot be empty", utils.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// decodeResource checks resp and decodes its data into target.
+// A response wrapping a single object ({"data": {...}}) is unwrapped.
+func decodeResource(resp *utils.Response, err error, target any) error {
+	if err != nil {
+		return err
+	}
+	if resp.Status != utils.StatusOK {
+		return fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	data := resp.Data
+	if object, ok := data.(map[string]any); ok && len(object) == 1 {
+		if inner, ok := object["data"].(map[string]any); ok {
+			data = inner
+		}
+	}
+	if _, ok := data.(map[string]any); !ok {
+		return fmt.Errorf("%w: expected an object, got %T", utils.ErrInvalidResponse, data)
+	}
+	if err := utils.UnmarshalData(data, target); err != nil {
+		return fmt.Errorf("%w: %w", utils.ErrInvalidResponse, err)
+	}
+	return nil
+}
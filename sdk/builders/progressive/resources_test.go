@@ -0,0 +1,198 @@
+package progressive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const testDataDockJSON = `{
+	"id": "dd-1",
+	"name": "postgres-prod",
+	"harbor_id": "test-harbor",
+	"status": "sleeping",
+	"connection_kind": {"Trino": {"host": "postgres.example.com", "port": 5432}},
+	"created_at": "2026-01-02T03:04:05Z",
+	"updated_at": "2026-01-03T03:04:05Z"
+}`
+
+func newResourceTestOrg(t *testing.T, payload string) (*OrgBuilder, *recordingClient) {
+	t.Helper()
+	var data any
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &data); err != nil {
+			t.Fatalf("Invalid test payload: %v", err)
+		}
+	}
+	client := newRecordingClient(data)
+	return &OrgBuilder{Client: client, OrgID: "test-org"}, client
+}
+
+func TestDataDockBuilder_Get(t *testing.T) {
+	org, client := newResourceTestOrg(t, testDataDockJSON)
+
+	dataDock, err := org.Harbor("test-harbor").DataDock("dd-1").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.last().endpoint != "https://test.example.com/data-docks/dd-1" {
+		t.Errorf("Unexpected endpoint %s", client.last().endpoint)
+	}
+	if dataDock.ID != "dd-1" || dataDock.Name != "postgres-prod" || dataDock.Status != DataDockSleeping {
+		t.Errorf("Unexpected datadock %+v", dataDock)
+	}
+	if dataDock.Connection.Type != "Trino" || dataDock.Connection.Config["host"] != "postgres.example.com" {
+		t.Errorf("Unexpected connection %+v", dataDock.Connection)
+	}
+	if !dataDock.CreatedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected created_at %v", dataDock.CreatedAt)
+	}
+}
+
+func TestListDataDocks_ResponseShapes(t *testing.T) {
+	for name, payload := range map[string]string{
+		"bare list":    `[` + testDataDockJSON + `]`,
+		"named field":  `{"data_docks": [` + testDataDockJSON + `], "total": 1}`,
+		"items field":  `{"items": [` + testDataDockJSON + `]}`,
+		"string kind":  `[{"id": "dd-1", "connection_kind": "TrinoInternal"}]`,
+		"wrapped data": `{"data": [` + testDataDockJSON + `]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			org, _ := newResourceTestOrg(t, payload)
+			dataDocks, err := org.Harbor("test-harbor").ListDataDocks(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(dataDocks) != 1 || dataDocks[0].ID != "dd-1" || dataDocks[0].Connection.Type == "" {
+				t.Errorf("Unexpected datadocks %+v", dataDocks)
+			}
+		})
+	}
+
+	org, _ := newResourceTestOrg(t, `{"unexpected": true}`)
+	if _, err := org.ListDataDocks(context.Background()); !errors.Is(err, utils.ErrInvalidResponse) {
+		t.Errorf("Expected ErrInvalidResponse, got %v", err)
+	}
+}
+
+func TestListHarbors(t *testing.T) {
+	org, client := newResourceTestOrg(t, `{"harbors": [{"id": "h-1", "name": "main"}, {"id": "h-2", "name": "staging"}]}`)
+
+	harbors, err := org.ListHarbors(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.last().endpoint != "https://test.example.com/test-org/harbors" {
+		t.Errorf("Unexpected endpoint %s", client.last().endpoint)
+	}
+	if len(harbors) != 2 || harbors[1].Name != "staging" {
+		t.Errorf("Unexpected harbors %+v", harbors)
+	}
+
+	org, _ = newResourceTestOrg(t, `null`)
+	if harbors, err := org.ListHarbors(context.Background()); err != nil || harbors == nil || len(harbors) != 0 {
+		t.Errorf("Expected an empty list, got %v (%v)", harbors, err)
+	}
+}
+
+func TestHarborBuilder_CreateDataDock(t *testing.T) {
+	org, client := newResourceTestOrg(t, testDataDockJSON)
+
+	req := CreateDataDockRequest{
+		Name:       "postgres-prod",
+		Connection: ConnectionKind{Type: "Trino", Config: map[string]any{"host": "postgres.example.com"}},
+	}
+	dataDock, err := org.Harbor("test-harbor").CreateDataDock(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dataDock.ID != "dd-1" {
+		t.Errorf("Unexpected datadock %+v", dataDock)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(client.last().body, &body); err != nil {
+		t.Fatalf("Invalid request body: %v", err)
+	}
+	if body["harbor_id"] != "test-harbor" || body["name"] != "postgres-prod" {
+		t.Errorf("Unexpected body %v", body)
+	}
+	if kind, ok := body["connection_kind"].(map[string]any); !ok || kind["Trino"] == nil {
+		t.Errorf("Expected connection_kind as {\"Trino\": {...}}, got %v", body["connection_kind"])
+	}
+	if client.last().method != "POST" || client.last().endpoint != "https://test.example.com/data-docks" {
+		t.Errorf("Unexpected request %s %s", client.last().method, client.last().endpoint)
+	}
+}
+
+func TestDataDockRequests_Validate(t *testing.T) {
+	org, client := newResourceTestOrg(t, testDataDockJSON)
+	harbor := org.Harbor("test-harbor")
+	dataDock := harbor.DataDock("dd-1")
+	empty := ""
+
+	for name, call := range map[string]func() error{
+		"create without name": func() error {
+			_, err := harbor.CreateDataDock(context.Background(), CreateDataDockRequest{Connection: ConnectionKind{Type: "Trino"}})
+			return err
+		},
+		"create without connection": func() error {
+			_, err := harbor.CreateDataDock(context.Background(), CreateDataDockRequest{Name: "x"})
+			return err
+		},
+		"empty update": func() error {
+			_, err := dataDock.Update(context.Background(), UpdateDataDockRequest{})
+			return err
+		},
+		"update to empty name": func() error {
+			_, err := dataDock.Update(context.Background(), UpdateDataDockRequest{Name: &empty})
+			return err
+		},
+		"create harbor without name": func() error {
+			_, err := org.CreateHarbor(context.Background(), "")
+			return err
+		},
+	} {
+		if err := call(); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("Expected invalid requests not to be sent, got %d", len(client.requests))
+	}
+}
+
+func TestDataDockBuilder_Update(t *testing.T) {
+	org, client := newResourceTestOrg(t, testDataDockJSON)
+	description := "Updated description"
+
+	dataDock, err := org.Harbor("test-harbor").DataDock("dd-1").Update(context.Background(), UpdateDataDockRequest{Description: &description})
+	if err != nil || dataDock.ID != "dd-1" {
+		t.Fatalf("Unexpected result %+v (%v)", dataDock, err)
+	}
+	if client.last().method != "PATCH" || string(client.last().body) != `{"description":"Updated description"}` {
+		t.Errorf("Unexpected request %s %s", client.last().method, client.last().body)
+	}
+}
+
+func TestConnectionKind_JSON(t *testing.T) {
+	for _, encoded := range []string{`"TrinoInternal"`, `{"Trino":{"host":"h"}}`} {
+		var kind ConnectionKind
+		if err := json.Unmarshal([]byte(encoded), &kind); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", encoded, err)
+		}
+		reencoded, _ := json.Marshal(kind)
+		if string(reencoded) != encoded {
+			t.Errorf("Expected %s to round-trip, got %s", encoded, reencoded)
+		}
+	}
+
+	var kind ConnectionKind
+	if err := json.Unmarshal([]byte(`{"Trino": {}, "Minio": {}}`), &kind); err == nil {
+		t.Error("Expected an error for several connection types")
+	}
+}
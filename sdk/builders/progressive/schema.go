@@ -3,15 +3,17 @@ package progressive
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
 
 // SchemaBuilder represents a schema context.
 // Available methods:
 //   - Table(name) - Navigate to a specific table (returns TableQueryBuilder for querying)
 //   - ListTables(ctx) - List all tables in this schema
+//   - Get(ctx) - Get the schema metadata as a typed Schema
 type SchemaBuilder struct {
 	client      builders.ClientInterface
 	orgID       string
@@ -24,59 +26,37 @@ type SchemaBuilder struct {
 // Returns a TableQueryBuilder which supports both queries and operations.
 func (s *SchemaBuilder) Table(tableName string) *TableQueryBuilder {
 	return &TableQueryBuilder{
-		client:      s.client,
-		orgID:       s.orgID,
-		catalogName: s.catalogName,
-		schemaName:  s.schemaName,
-		tableName:   tableName,
-		// Query builder fields
-		selectCols: []string{},
-		filters:    []builders.Filter{},
-		orderBy:    []builders.OrderClause{},
-		rawParams:  url.Values{},
+		QueryBuilder: fluent.NewQueryBuilder(s.client).
+			DataDock(s.dataDockID).
+			Catalog(s.catalogName).
+			Schema(s.schemaName).
+			Table(tableName),
 	}
 }
 
 // ListTables retrieves all tables in this schema.
+// It returns utils.ErrNotFound if the catalog or schema does not exist.
 func (s *SchemaBuilder) ListTables(ctx context.Context) ([]string, error) {
-	// Get full catalog metadata
-	endpoint := fmt.Sprintf("%s/data-docks/%s/catalog",
-		s.client.GetConfig().BaseURL,
-		url.PathEscape(s.dataDockID),
-	)
-
-	resp, err := s.client.Do(ctx, "GET", endpoint, nil)
+	schema, err := s.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return schema.TableNames(), nil
+}
 
-	// Extract tables for this schema
-	var tables []string
-	if catalogs, ok := resp.Data.(map[string]interface{})["catalogs"].([]interface{}); ok {
-		for _, cat := range catalogs {
-			if catMap, ok := cat.(map[string]interface{}); ok {
-				if catMap["catalog_name"] == s.catalogName {
-					if schemaList, ok := catMap["schemas"].([]interface{}); ok {
-						for _, sch := range schemaList {
-							if schMap, ok := sch.(map[string]interface{}); ok {
-								if schMap["schema_name"] == s.schemaName {
-									if tableList, ok := schMap["tables"].([]interface{}); ok {
-										for _, t := range tableList {
-											if tMap, ok := t.(map[string]interface{}); ok {
-												if name, ok := tMap["table_name"].(string); ok {
-													tables = append(tables, name)
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
+// Get retrieves the metadata of this schema (tables and columns).
+func (s *SchemaBuilder) Get(ctx context.Context) (*Schema, error) {
+	catalogs, err := builders.FetchCatalogs(ctx, s.client, s.dataDockID)
+	if err != nil {
+		return nil, err
 	}
-
-	return tables, nil
+	catalog, ok := builders.FindCatalog(catalogs, s.catalogName)
+	if !ok {
+		return nil, fmt.Errorf("%w: catalog %q", utils.ErrNotFound, s.catalogName)
+	}
+	schema, ok := catalog.Schema(s.schemaName)
+	if !ok {
+		return nil, fmt.Errorf("%w: schema %q in catalog %q", utils.ErrNotFound, s.schemaName, s.catalogName)
+	}
+	return schema, nil
 }
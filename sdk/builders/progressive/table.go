@@ -1,149 +1,115 @@
 package progressive
 
 import (
-	"context"
-	"fmt"
 	"net/url"
 
-	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
-	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
 )
 
 // TableQueryBuilder combines table navigation with query building.
 // This is the final level where you can build queries AND execute them.
-// Inherits all query building methods from the original QueryBuilder.
+//
+// It is a thin wrapper over fluent.QueryBuilder: the data dock, catalog,
+// schema and table are pre-set from the navigation path, chaining methods
+// return *TableQueryBuilder, and every terminal operation (Get, Count, Post,
+// Put, Delete, Aggregate, Build, Explain, ...) is the fluent one, so both APIs
+// always send identical requests.
 type TableQueryBuilder struct {
-	client builders.ClientInterface
-	orgID  string
+	*fluent.QueryBuilder
+}
+
+// wrap returns a TableQueryBuilder around the given (new) fluent builder.
+func (t *TableQueryBuilder) wrap(qb *fluent.QueryBuilder) *TableQueryBuilder {
+	return &TableQueryBuilder{QueryBuilder: qb}
+}
 
-	// Table location
-	catalogName string
-	schemaName  string
-	tableName   string
+// Query returns the underlying fluent.QueryBuilder.
+func (t *TableQueryBuilder) Query() *fluent.QueryBuilder {
+	return t.QueryBuilder
+}
 
-	// Query parameters (same as QueryBuilder)
-	selectCols []string
-	filters    []builders.Filter
-	orderBy    []builders.OrderClause
-	limitVal   int
-	offsetVal  int
-	rawParams  url.Values
+// Clone returns a deep copy of the builder.
+func (t *TableQueryBuilder) Clone() *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Clone())
 }
 
-// Query building methods - same as original QueryBuilder
-// These return *TableQueryBuilder for chaining
+// Query building methods - see fluent.QueryBuilder for details.
+// These return *TableQueryBuilder for chaining.
 
 func (t *TableQueryBuilder) Select(columns ...string) *TableQueryBuilder {
-	t.selectCols = append(t.selectCols, columns...)
-	return t
+	return t.wrap(t.QueryBuilder.Select(columns...))
 }
 
 func (t *TableQueryBuilder) Where(column, operator string, value interface{}) *TableQueryBuilder {
-	t.filters = append(t.filters, builders.Filter{
-		Column:   column,
-		Operator: operator,
-		Value:    value,
-	})
-	return t
+	return t.wrap(t.QueryBuilder.Where(column, operator, value))
 }
 
 func (t *TableQueryBuilder) OrderBy(column, direction string) *TableQueryBuilder {
-	if direction == "" {
-		direction = "ASC"
-	}
-	t.orderBy = append(t.orderBy, builders.OrderClause{
-		Column:    column,
-		Direction: direction,
-	})
-	return t
+	return t.wrap(t.QueryBuilder.OrderBy(column, direction))
 }
 
 func (t *TableQueryBuilder) Limit(n int) *TableQueryBuilder {
-	t.limitVal = n
-	return t
+	return t.wrap(t.QueryBuilder.Limit(n))
 }
 
 func (t *TableQueryBuilder) Offset(n int) *TableQueryBuilder {
-	t.offsetVal = n
-	return t
+	return t.wrap(t.QueryBuilder.Offset(n))
 }
 
 func (t *TableQueryBuilder) RawParams(params url.Values) *TableQueryBuilder {
-	for key, values := range params {
-		for _, value := range values {
-			t.rawParams.Add(key, value)
-		}
-	}
-	return t
-}
-
-// Execution method - builds the query and executes it
-
-func (t *TableQueryBuilder) Get(ctx context.Context) (*utils.Response, error) {
-	// Build endpoint using Bifrost OpenAPI format
-	endpoint := fmt.Sprintf(
-		"%s/%s/openapi/%s/%s/%s",
-		t.client.GetConfig().BaseURL,
-		url.PathEscape(t.orgID),
-		url.PathEscape(t.catalogName),
-		url.PathEscape(t.schemaName),
-		url.PathEscape(t.tableName),
-	)
-
-	// Build query parameters using the same logic as QueryBuilder
-	params := t.buildParams()
-
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
-	}
-
-	return t.client.Do(ctx, "GET", endpoint, nil)
-}
-
-// buildParams constructs query parameters (same as QueryBuilder)
-func (t *TableQueryBuilder) buildParams() url.Values {
-	params := url.Values{}
-
-	// Copy raw params first
-	for key, values := range t.rawParams {
-		for _, value := range values {
-			params.Add(key, value)
-		}
-	}
-
-	// Add SELECT columns
-	if len(t.selectCols) > 0 {
-		params.Set("select", fmt.Sprintf("%s", t.selectCols))
-	}
-
-	// Add WHERE filters
-	for _, filter := range t.filters {
-		paramName := fmt.Sprintf("%s[%s]", filter.Column, filter.Operator)
-		params.Add(paramName, fmt.Sprintf("%v", filter.Value))
-	}
-
-	// Add ORDER BY
-	if len(t.orderBy) > 0 {
-		var orderParts []string
-		for _, order := range t.orderBy {
-			if order.Direction == "DESC" {
-				orderParts = append(orderParts, fmt.Sprintf("%s.desc", order.Column))
-			} else {
-				orderParts = append(orderParts, fmt.Sprintf("%s.asc", order.Column))
-			}
-		}
-		params.Set("order", fmt.Sprintf("%s", orderParts))
-	}
-
-	// Add LIMIT
-	if t.limitVal > 0 {
-		params.Set("_limit", fmt.Sprintf("%d", t.limitVal))
-	}
-
-	// Add OFFSET
-	if t.offsetVal > 0 {
-		params.Set("_offset", fmt.Sprintf("%d", t.offsetVal))
-	}
-
-	return params
+	return t.wrap(t.QueryBuilder.RawParams(params))
+}
+
+func (t *TableQueryBuilder) DryRun() *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.DryRun())
+}
+
+func (t *TableQueryBuilder) AllRows() *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.AllRows())
+}
+
+func (t *TableQueryBuilder) Strict() *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Strict())
+}
+
+func (t *TableQueryBuilder) StrictSchema(table *Table) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.StrictSchema(table))
+}
+
+// Aggregation methods
+
+func (t *TableQueryBuilder) Sum(column string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Sum(column))
+}
+
+func (t *TableQueryBuilder) Avg(column string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Avg(column))
+}
+
+func (t *TableQueryBuilder) Min(column string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Min(column))
+}
+
+func (t *TableQueryBuilder) Max(column string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Max(column))
+}
+
+func (t *TableQueryBuilder) CountAll() *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.CountAll())
+}
+
+func (t *TableQueryBuilder) CountDistinct(column string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.CountDistinct(column))
+}
+
+func (t *TableQueryBuilder) As(alias string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.As(alias))
+}
+
+func (t *TableQueryBuilder) GroupBy(columns ...string) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.GroupBy(columns...))
+}
+
+func (t *TableQueryBuilder) Having(alias, operator string, value interface{}) *TableQueryBuilder {
+	return t.wrap(t.QueryBuilder.Having(alias, operator, value))
 }
@@ -0,0 +1,201 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+func newTestTable(client *recordingClient) *TableQueryBuilder {
+	org := &OrgBuilder{Client: client, OrgID: "test-org"}
+	return org.Harbor("test-harbor").DataDock("test-datadock").Catalog("sales").Schema("public").Table("orders")
+}
+
+func newTestFluent(client *recordingClient) *fluent.QueryBuilder {
+	return fluent.NewQueryBuilder(client).DataDock("test-datadock").Catalog("sales").Schema("public").Table("orders")
+}
+
+func TestTableQueryBuilder_MatchesFluentURLs(t *testing.T) {
+	client := newRecordingClient(nil)
+
+	tests := []struct {
+		name        string
+		progressive func(*TableQueryBuilder) *TableQueryBuilder
+		fluent      func(*fluent.QueryBuilder) *fluent.QueryBuilder
+	}{
+		{
+			name:        "plain table",
+			progressive: func(t *TableQueryBuilder) *TableQueryBuilder { return t },
+			fluent:      func(q *fluent.QueryBuilder) *fluent.QueryBuilder { return q },
+		},
+		{
+			name: "select, filters, order and paging",
+			progressive: func(t *TableQueryBuilder) *TableQueryBuilder {
+				return t.Select("id", "total").Where("status", "=", "paid").Where("total", ">", 10).
+					OrderBy("created_at", "desc").OrderBy("id", "").Limit(20).Offset(40)
+			},
+			fluent: func(q *fluent.QueryBuilder) *fluent.QueryBuilder {
+				return q.Select("id", "total").Where("status", "=", "paid").Where("total", ">", 10).
+					OrderBy("created_at", "desc").OrderBy("id", "").Limit(20).Offset(40)
+			},
+		},
+		{
+			name: "aggregates",
+			progressive: func(t *TableQueryBuilder) *TableQueryBuilder {
+				return t.GroupBy("region").Sum("total").As("revenue").CountAll().Having("revenue", ">", 100)
+			},
+			fluent: func(q *fluent.QueryBuilder) *fluent.QueryBuilder {
+				return q.GroupBy("region").Sum("total").As("revenue").CountAll().Having("revenue", ">", 100)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progressivePlan, err := tt.progressive(newTestTable(client)).Build()
+			if err != nil {
+				t.Fatalf("Progressive build failed: %v", err)
+			}
+			fluentPlan, err := tt.fluent(newTestFluent(client)).Build()
+			if err != nil {
+				t.Fatalf("Fluent build failed: %v", err)
+			}
+			if progressivePlan.URL() != fluentPlan.URL() {
+				t.Errorf("URLs differ:\nprogressive: %s\nfluent:      %s", progressivePlan.URL(), fluentPlan.URL())
+			}
+		})
+	}
+}
+
+func TestTableQueryBuilder_UsesDataDockAndJoinsSelect(t *testing.T) {
+	client := newRecordingClient([]any{})
+
+	_, err := newTestTable(client).Select("a", "b").OrderBy("a", "DESC").OrderBy("b", "ASC").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := client.last()
+	if !strings.HasPrefix(req.endpoint, "https://test.example.com/test-datadock/openapi/sales/public/orders?") {
+		t.Errorf("Expected data dock ID in URL, got %s", req.endpoint)
+	}
+	if !strings.Contains(req.endpoint, "select=a%2Cb") {
+		t.Errorf("Expected select=a,b, got %s", req.endpoint)
+	}
+	if !strings.Contains(req.endpoint, "order=a.desc%2Cb.asc") {
+		t.Errorf("Expected order=a.desc,b.asc, got %s", req.endpoint)
+	}
+}
+
+func TestTableQueryBuilder_Validation(t *testing.T) {
+	client := newRecordingClient(nil)
+
+	if _, err := newTestTable(client).Where("id", "??", 1).Get(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid operator") {
+		t.Errorf("Expected invalid operator error, got %v", err)
+	}
+	if _, err := newTestTable(client).OrderBy("id", "sideways").Get(context.Background()); err == nil || !strings.Contains(err.Error(), "must be ASC or DESC") {
+		t.Errorf("Expected invalid direction error, got %v", err)
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("Invalid queries should not be sent, got %d requests", len(client.requests))
+	}
+}
+
+func TestTableQueryBuilder_WriteOperations(t *testing.T) {
+	client := newRecordingClient(map[string]any{"count": float64(3)})
+	table := newTestTable(client)
+	ctx := context.Background()
+
+	count, err := table.Where("status", "=", "paid").Count(ctx)
+	if err != nil || count != 3 {
+		t.Errorf("Expected count 3, got %d (%v)", count, err)
+	}
+	if _, err := table.Post(ctx, map[string]any{"id": 1}); err != nil {
+		t.Errorf("Post failed: %v", err)
+	}
+	if _, err := table.Where("id", "=", 1).Put(ctx, map[string]any{"status": "paid"}); err != nil {
+		t.Errorf("Put failed: %v", err)
+	}
+	if _, err := table.Where("id", "=", 1).Delete(ctx); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+
+	methods := []string{}
+	for _, req := range client.requests {
+		methods = append(methods, req.method)
+	}
+	if strings.Join(methods, ",") != "GET,POST,PUT,DELETE" {
+		t.Errorf("Unexpected request sequence: %v", methods)
+	}
+}
+
+func TestTableQueryBuilder_AllRows(t *testing.T) {
+	client := newRecordingClient(nil)
+	ctx := context.Background()
+
+	if _, err := newTestTable(client).Delete(ctx); !errors.Is(err, utils.ErrUnfilteredWrite) {
+		t.Errorf("Expected ErrUnfilteredWrite, got %v", err)
+	}
+	if _, err := newTestTable(client).AllRows().Delete(ctx); err != nil {
+		t.Errorf("Expected AllRows Delete to succeed, got %v", err)
+	}
+	if len(client.requests) != 1 {
+		t.Errorf("Expected exactly one request, got %d", len(client.requests))
+	}
+}
+
+func TestTableQueryBuilder_DescribeAndStrict(t *testing.T) {
+	dataDock, client := newCatalogTestDataDock(t, testCatalogPayload)
+	orders := dataDock.Catalog("sales").Schema("public").Table("orders")
+	ctx := context.Background()
+
+	table, err := orders.Describe(ctx)
+	if err != nil || table.ColumnNames()[0] != "id" {
+		t.Fatalf("Unexpected table %+v (%v)", table, err)
+	}
+
+	before := len(client.requests)
+	if _, err := orders.Strict().Where("missing", "=", 1).Get(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+	if last := client.last(); len(client.requests) != before+1 || !strings.HasSuffix(last.endpoint, "/catalog") {
+		t.Errorf("Expected only the catalog lookup to be sent, got %+v", client.requests[before:])
+	}
+}
+
+// recordingClient records requests and answers every request with the same data.
+type recordingClient struct {
+	config   utils.Configuration
+	data     any
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	method   string
+	endpoint string
+	body     []byte
+}
+
+func newRecordingClient(data any) *recordingClient {
+	return &recordingClient{
+		config: utils.Configuration{BaseURL: "https://test.example.com", Token: "test-token"},
+		data:   data,
+	}
+}
+
+func (c *recordingClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	c.requests = append(c.requests, recordedRequest{method: method, endpoint: endpoint, body: body})
+	return &utils.Response{Status: utils.StatusOK, Data: c.data, HTTPCode: 200}, nil
+}
+
+func (c *recordingClient) GetConfig() utils.Configuration {
+	return c.config
+}
+
+func (c *recordingClient) last() recordedRequest {
+	return c.requests[len(c.requests)-1]
+}
@@ -19,6 +19,13 @@ type OrderClause struct {
 	Direction string // ASC or DESC
 }
 
+// Aggregate represents an aggregate expression in the SELECT list.
+type Aggregate struct {
+	Function string // SUM, AVG, MIN, MAX, COUNT, COUNT_DISTINCT
+	Column   string
+	Alias    string
+}
+
 type Builder interface {
 	validate() error
 }
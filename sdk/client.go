@@ -3,8 +3,12 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/fluent"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
@@ -12,21 +16,31 @@ import (
 
 // Client is the main entry point for the SDK.
 type Client struct {
-	config     utils.Configuration
-	httpClient *http.Client
+	config       utils.Configuration
+	httpClient   *http.Client
+	catalogCache *builders.CatalogCache
+
+	wakeMu           sync.Mutex
+	wakeCalls        map[string]*wakeCall
+	wakePollInterval time.Duration // Zero uses progressive.DefaultWaitInterval
 }
 
 // NewClient creates a new Bifrost client with the provided configuration.
 func NewClient(config utils.Configuration) *Client {
 	// Create a copy of the configuration to avoid side effects
 	cfg := config
-	return &Client{
+	client := &Client{
 		config: cfg,
 		httpClient: utils.CreateHTTPClientWithSettings(
 			cfg.SkipTLSVerify,
 			cfg.RequestTimeout,
 		),
 	}
+
+	if cfg.CatalogCacheTTL > 0 {
+		client.catalogCache = builders.NewCatalogCache(cfg.CatalogCacheTTL)
+	}
+	return client
 }
 
 // NewClientFromServiceAccount creates a new Bifrost client using a ServiceAccount.
@@ -105,7 +119,19 @@ func NewClientFromServiceAccountJSON(jsonStr string, opts ServiceAccountOptions)
 
 // Do executes an HTTP request (implements the interface needed by builders)
 func (c *Client) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
-	return c.do(ctx, method, endpoint, body)
+	return c.do(ctx, method, endpoint, body, nil)
+}
+
+// DoWithHeaders executes an HTTP request with extra request headers
+// (implements builders.HeaderClientInterface)
+func (c *Client) DoWithHeaders(ctx context.Context, method, endpoint string, body []byte, headers http.Header) (*utils.Response, error) {
+	return c.do(ctx, method, endpoint, body, headers)
+}
+
+// CatalogCache returns the client's catalog metadata cache, or nil if it is
+// disabled (Configuration.CatalogCacheTTL is not positive).
+func (c *Client) CatalogCache() *builders.CatalogCache {
+	return c.catalogCache
 }
 
 // GetConfig returns the client configuration (implements the interface needed by builders)
@@ -147,9 +173,9 @@ func (c *Client) Catalog(name string) *fluent.QueryBuilder {
 //	client.Org(id).Harbor(id).DataDock(id).Catalog(name).Schema(name).Table(name)
 //
 // Each level provides contextual methods:
-//   - Org: ListHarbors(), CreateHarbor(), ListDataDocks()
-//   - Harbor: ListDataDocks(), CreateDataDock(), Delete()
-//   - DataDock: GetCatalog(), RefreshCatalog(), WakeUp(), Sleep()
+//   - Org: Get(), ListMembers(), ListHarbors(), CreateHarbor(), ListDataDocks() and All* iterators
+//   - Harbor: Get(), Update(), ListDataDocks(), AllDataDocks(), CreateDataDock(), Delete()
+//   - DataDock: Get(), Update(), GetCatalog(), RefreshCatalog(), WakeUp(), Sleep()
 //   - Catalog: Schema(), ListSchemas()
 //   - Schema: Table(), ListTables()
 //   - Table: Select(), Where(), Limit(), Get()
@@ -175,6 +201,17 @@ func (c *Client) DataDock(dataDockID string) *fluent.QueryBuilder {
 	return fluent.NewQueryBuilder(c).DataDock(dataDockID)
 }
 
+// ListOrgs retrieves one page of the organizations visible to the client's credentials.
+func (c *Client) ListOrgs(ctx context.Context, opts ...progressive.ListOptions) ([]progressive.Org, error) {
+	return progressive.ListOrgs(ctx, c, opts...)
+}
+
+// AllOrgs iterates over the organizations visible to the client's
+// credentials, fetching pages as needed.
+func (c *Client) AllOrgs(ctx context.Context, opts progressive.ListOptions) iter.Seq2[progressive.Org, error] {
+	return progressive.AllOrgs(ctx, c, opts)
+}
+
 // OrgFromConfig creates an OrgBuilder using the OrgID from the client configuration.
 // This is a convenience method when you always use the same organization.
 func (c *Client) OrgFromConfig() *progressive.OrgBuilder {
@@ -184,6 +221,17 @@ func (c *Client) OrgFromConfig() *progressive.OrgBuilder {
 	}
 }
 
+// SQL creates a new SQLBuilder for raw SQL queries against a data dock.
+// Uses DataDockID from config when dataDockID is empty.
+// Example:
+//
+//	result, err := client.SQL("datadock-id").Query(ctx,
+//	    "SELECT o.id, c.name FROM sales.public.orders o JOIN crm.public.customers c ON c.id = o.customer_id WHERE o.id = ?",
+//	    42)
+func (c *Client) SQL(dataDockID string) *fluent.SQLBuilder {
+	return fluent.NewSQLBuilder(c, dataDockID)
+}
+
 // Search creates a new SearchBuilder for full-text search queries.
 // Example:
 //
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders"
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
 
@@ -72,6 +74,33 @@ func TestFluentAPI_Success(t *testing.T) {
 	}
 }
 
+func TestFluentAPI_ArrowStream(t *testing.T) {
+	stream := []byte("ARROW1\x00\x00")
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "test-datadock", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": {utils.ArrowStreamMediaType + "; charset=binary"}},
+						Body:       io.NopCloser(strings.NewReader(string(stream))),
+					}, nil
+				},
+			},
+		},
+	}
+
+	resp, err := client.DoWithHeaders(context.Background(), "GET", "https://test.example.com/t", nil,
+		http.Header{"Accept": {utils.ArrowStreamMediaType}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, ok := resp.Data.([]byte); !ok || string(data) != string(stream) {
+		t.Errorf("Expected the raw stream, got %v", resp.Data)
+	}
+}
+
 func TestFluentAPI_NotFound(t *testing.T) {
 	config := utils.Configuration{
 		Token:      "test-token",
@@ -171,6 +200,104 @@ func TestFluentAPI_ServerError_Retry(t *testing.T) {
 	}
 }
 
+func TestFluentAPI_RateLimited_Retry(t *testing.T) {
+	reqCount := 0
+	config := utils.Configuration{
+		Token:      "test-token",
+		DataDockID: "test-datadock",
+		BaseURL:    "https://test.example.com",
+		MaxRetries: 1,
+	}
+
+	client := &Client{
+		config: config,
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					reqCount++
+					if reqCount == 1 {
+						return &http.Response{
+							StatusCode: http.StatusTooManyRequests,
+							Header:     http.Header{},
+							Body:       io.NopCloser(strings.NewReader("slow down")),
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: http.StatusCreated,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		},
+	}
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Post(context.Background(), []map[string]any{{"id": 1}})
+
+	if err != nil {
+		t.Fatalf("Expected no error on retry, got %v", err)
+	}
+	if reqCount != 2 {
+		t.Errorf("Expected 2 requests, got %d", reqCount)
+	}
+}
+
+func TestCatalogCache_RevalidationAndRefresh(t *testing.T) {
+	var requests []string
+	client := NewClient(utils.Configuration{
+		Token:           "test-token",
+		OrgID:           "test-org",
+		BaseURL:         "https://test.example.com",
+		CatalogCacheTTL: time.Hour,
+	})
+	client.httpClient = &http.Client{
+		Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.Method+" "+req.URL.Path+" "+req.Header.Get("If-None-Match"))
+				if req.Header.Get("If-None-Match") == `"v1"` {
+					return &http.Response{StatusCode: http.StatusNotModified, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Etag": {`"v1"`}},
+					Body:       io.NopCloser(strings.NewReader(`{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public"}]}]}`)),
+				}, nil
+			},
+		},
+	}
+
+	ctx := context.Background()
+	dataDock := client.Org("test-org").Harbor("h").DataDock("dd")
+	for range 3 {
+		if schemas, err := dataDock.Catalog("sales").ListSchemas(ctx); err != nil || len(schemas) != 1 {
+			t.Fatalf("Unexpected schemas %v (%v)", schemas, err)
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected one catalog fetch, got %v", requests)
+	}
+
+	if _, err := dataDock.RefreshCatalog(ctx); err != nil {
+		t.Fatalf("RefreshCatalog failed: %v", err)
+	}
+	if _, err := dataDock.Catalog("sales").ListSchemas(ctx); err != nil {
+		t.Fatalf("ListSchemas failed: %v", err)
+	}
+	if len(requests) != 3 || !strings.HasPrefix(requests[2], "GET /data-docks/dd/catalog") {
+		t.Errorf("Expected a refetch after RefreshCatalog, got %v", requests)
+	}
+
+	// An expired entry is revalidated; 304 keeps the cached catalogs
+	client.catalogCache = builders.NewCatalogCache(time.Nanosecond)
+	for range 2 {
+		if schemas, err := dataDock.Catalog("sales").ListSchemas(ctx); err != nil || len(schemas) != 1 {
+			t.Fatalf("Unexpected schemas %v (%v)", schemas, err)
+		}
+	}
+	if requests[len(requests)-1] != `GET /data-docks/dd/catalog "v1"` {
+		t.Errorf("Expected an If-None-Match revalidation, got %v", requests)
+	}
+}
+
 // mockRoundTripper is used to mock HTTP responses in tests.
 type mockRoundTripper struct {
 	roundTripFunc func(req *http.Request) (*http.Response, error)
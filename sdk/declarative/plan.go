@@ -0,0 +1,413 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Action is what a Change does to a resource.
+type Action string
+
+// Actions a Change can take.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// ResourceKind is the type of resource a Change applies to.
+type ResourceKind string
+
+// Resource kinds managed by a Spec.
+const (
+	KindHarbor   ResourceKind = "harbor"
+	KindDataDock ResourceKind = "datadock"
+)
+
+// Change is one step of a ChangeSet.
+type Change struct {
+	Action Action
+	Kind   ResourceKind
+	Harbor string   // Name of the harbor (of the datadock)
+	Name   string   // Name of the datadock; empty for harbors
+	ID     string   // Live ID, for updates and deletes
+	Fields []string // Fields an update changes, e.g. "description", "connection.host"
+
+	create progressive.CreateDataDockRequest
+	update progressive.UpdateDataDockRequest
+}
+
+// Target names the changed resource, e.g. "datadock analytics/postgres-prod".
+func (c Change) Target() string {
+	if c.Kind == KindDataDock {
+		return fmt.Sprintf("%s %s/%s", c.Kind, c.Harbor, c.Name)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, c.Harbor)
+}
+
+// String describes the change on one line, without connection settings.
+func (c Change) String() string {
+	symbol := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+	line := symbol + " " + c.Target()
+	switch {
+	case c.Action == ActionCreate && c.Kind == KindDataDock:
+		line += fmt.Sprintf(" (%s)", c.create.Connection.Type)
+	case c.Action == ActionUpdate:
+		line += fmt.Sprintf(" (%s)", strings.Join(c.Fields, ", "))
+	case c.Action == ActionDelete:
+		line += fmt.Sprintf(" (%s)", c.ID)
+	}
+	return line
+}
+
+// ChangeSet is the result of Plan: the changes that bring an organization
+// to its spec, in the order Apply executes them.
+type ChangeSet struct {
+	OrgID   string
+	Changes []Change
+
+	org       *progressive.OrgBuilder
+	harborIDs map[string]string // Live harbors by name
+}
+
+// Empty reports whether the organization already matches the spec.
+func (cs *ChangeSet) Empty() bool {
+	return len(cs.Changes) == 0
+}
+
+// Count returns the number of changes with the given action.
+func (cs *ChangeSet) Count(action Action) int {
+	n := 0
+	for _, change := range cs.Changes {
+		if change.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// String renders the plan, one change per line.
+func (cs *ChangeSet) String() string {
+	if cs.Empty() {
+		return fmt.Sprintf("No changes: org %s matches the spec.\n", cs.OrgID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan for org %s: %d to create, %d to update, %d to delete.\n\n",
+		cs.OrgID, cs.Count(ActionCreate), cs.Count(ActionUpdate), cs.Count(ActionDelete))
+	for _, change := range cs.Changes {
+		fmt.Fprintf(&b, "  %s\n", change)
+	}
+	return b.String()
+}
+
+// Plan reads the live harbors and datadocks of org and computes the changes
+// that bring them to spec. Nothing is modified.
+func Plan(ctx context.Context, org *progressive.OrgBuilder, spec *Spec) (*ChangeSet, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if spec.Org != "" && spec.Org != org.OrgID {
+		return nil, fmt.Errorf("%w: the spec is for org %s, not %s", utils.ErrInvalidRequest, spec.Org, org.OrgID)
+	}
+
+	harbors, dataDocks, err := liveState(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	var creates, updates, deletes []Change
+	declared := map[string]bool{}
+	for _, harborSpec := range spec.Harbors {
+		declared[harborSpec.Name] = true
+		harbor, exists := harbors[harborSpec.Name]
+		if !exists {
+			creates = append(creates, Change{Action: ActionCreate, Kind: KindHarbor, Harbor: harborSpec.Name})
+		}
+
+		live := map[string]progressive.DataDock{}
+		if exists {
+			live = dataDocks[harbor.ID]
+		}
+		declaredDocks := map[string]bool{}
+		for _, dockSpec := range harborSpec.DataDocks {
+			declaredDocks[dockSpec.Name] = true
+			connection, err := dockSpec.Connection.Kind()
+			if err != nil {
+				return nil, err
+			}
+
+			dataDock, found := live[dockSpec.Name]
+			if !found {
+				creates = append(creates, Change{
+					Action: ActionCreate,
+					Kind:   KindDataDock,
+					Harbor: harborSpec.Name,
+					Name:   dockSpec.Name,
+					create: progressive.CreateDataDockRequest{
+						Name:        dockSpec.Name,
+						Description: dockSpec.Description,
+						Connection:  connection,
+					},
+				})
+				continue
+			}
+			if change, changed := diffDataDock(dataDock, dockSpec, connection); changed {
+				change.Harbor = harborSpec.Name
+				updates = append(updates, change)
+			}
+		}
+
+		if spec.Prune {
+			for _, dataDock := range sortedByName(live) {
+				if !declaredDocks[dataDock.Name] {
+					deletes = append(deletes, deleteDataDock(harborSpec.Name, dataDock))
+				}
+			}
+		}
+	}
+
+	if spec.Prune {
+		for _, harbor := range sortedByName(harbors) {
+			if declared[harbor.Name] {
+				continue
+			}
+			for _, dataDock := range sortedByName(dataDocks[harbor.ID]) {
+				deletes = append(deletes, deleteDataDock(harbor.Name, dataDock))
+			}
+			deletes = append(deletes, Change{Action: ActionDelete, Kind: KindHarbor, Harbor: harbor.Name, ID: harbor.ID})
+		}
+	}
+
+	// Harbors are created before their datadocks, and datadocks deleted
+	// before their harbor; deletes come last so a failed apply keeps data.
+	changes := slices.Concat(creates, updates, deletes)
+	harborIDs := map[string]string{}
+	for name, harbor := range harbors {
+		harborIDs[name] = harbor.ID
+	}
+	return &ChangeSet{OrgID: org.OrgID, Changes: changes, org: org, harborIDs: harborIDs}, nil
+}
+
+// liveState lists the harbors of org by name, and their datadocks by
+// harbor ID and name.
+func liveState(ctx context.Context, org *progressive.OrgBuilder) (map[string]progressive.Harbor, map[string]map[string]progressive.DataDock, error) {
+	harbors := map[string]progressive.Harbor{}
+	for harbor, err := range org.AllHarbors(ctx, progressive.ListOptions{}) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing harbors: %w", err)
+		}
+		if _, ok := harbors[harbor.Name]; ok {
+			return nil, nil, fmt.Errorf("%w: several harbors are named %s", utils.ErrInvalidResponse, harbor.Name)
+		}
+		harbors[harbor.Name] = harbor
+	}
+
+	dataDocks := map[string]map[string]progressive.DataDock{}
+	for dataDock, err := range org.AllDataDocks(ctx, progressive.ListOptions{}) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing datadocks: %w", err)
+		}
+		byName := dataDocks[dataDock.HarborID]
+		if byName == nil {
+			byName = map[string]progressive.DataDock{}
+			dataDocks[dataDock.HarborID] = byName
+		}
+		if _, ok := byName[dataDock.Name]; ok {
+			return nil, nil, fmt.Errorf("%w: several datadocks of harbor %s are named %s", utils.ErrInvalidResponse, dataDock.HarborID, dataDock.Name)
+		}
+		byName[dataDock.Name] = dataDock
+	}
+	return harbors, dataDocks, nil
+}
+
+// diffDataDock compares a live datadock with its spec.
+func diffDataDock(live progressive.DataDock, spec DataDockSpec, connection progressive.ConnectionKind) (Change, bool) {
+	change := Change{Action: ActionUpdate, Kind: KindDataDock, Name: spec.Name, ID: live.ID}
+	if live.Description != spec.Description {
+		change.Fields = append(change.Fields, "description")
+		change.update.Description = &spec.Description
+	}
+
+	var connectionFields []string
+	if live.Connection.Type != connection.Type {
+		connectionFields = append(connectionFields, "connection.type")
+	}
+	for _, key := range diffConnectionConfig(live.Connection.Config, connection.Config) {
+		connectionFields = append(connectionFields, "connection."+key)
+	}
+	if len(connectionFields) > 0 {
+		change.Fields = append(change.Fields, connectionFields...)
+		change.update.Connection = &connection
+	}
+	return change, len(change.Fields) > 0
+}
+
+func deleteDataDock(harbor string, dataDock progressive.DataDock) Change {
+	return Change{Action: ActionDelete, Kind: KindDataDock, Harbor: harbor, Name: dataDock.Name, ID: dataDock.ID}
+}
+
+// diffConnectionConfig returns the spec config keys whose value differs from
+// the live one, in key order. Only keys the server echoes back are compared:
+// secrets it omits or redacts count as unchanged, and so do keys it adds,
+// such as defaults.
+func diffConnectionConfig(live, spec map[string]any) []string {
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(spec)) {
+		liveValue, echoed := live[key]
+		if echoed && !sameConfigValue(liveValue, spec[key]) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// sameConfigValue compares a live config value with its spec value, both in
+// their JSON types. A redacted live value matches anything, and scalars
+// match across types when their text is the same (5432 and "5432").
+func sameConfigValue(live, spec any) bool {
+	if text, ok := live.(string); ok && isRedacted(text) {
+		return true
+	}
+	if spec, ok := spec.(map[string]any); ok {
+		live, ok := live.(map[string]any)
+		return ok && len(diffConnectionConfig(live, spec)) == 0
+	}
+	liveText, liveScalar := scalarText(live)
+	specText, specScalar := scalarText(spec)
+	if liveScalar && specScalar {
+		return liveText == specText
+	}
+	return reflect.DeepEqual(live, spec)
+}
+
+// isRedacted reports whether a live config string stands for a secret the
+// server does not echo back, such as "********" or "[REDACTED]".
+func isRedacted(value string) bool {
+	if value != "" && strings.Trim(value, "*") == "" {
+		return true
+	}
+	return strings.EqualFold(strings.Trim(value, "[]<>"), "redacted")
+}
+
+// scalarText returns the text of a string, number or boolean JSON value.
+func scalarText(value any) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+	return "", false
+}
+
+// sortedByName returns the values of a map keyed by name, in name order.
+func sortedByName[T any](byName map[string]T) []T {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	values := make([]T, len(names))
+	for i, name := range names {
+		values[i] = byName[name]
+	}
+	return values
+}
+
+// ApplyOptions control Apply.
+type ApplyOptions struct {
+	// Output receives the plan before any change is made, then one line per
+	// applied change. Nil discards it.
+	Output io.Writer
+	// Confirm is called with the plan before any change is made; Apply
+	// stops without error when it returns false. Nil applies directly.
+	Confirm func(*ChangeSet) bool
+}
+
+// Apply shows the plan, then executes the changes in order. It stops at the
+// first failure; the changes before it stay applied, so planning again
+// resumes from there.
+func (cs *ChangeSet) Apply(ctx context.Context, opts ApplyOptions) error {
+	out := opts.Output
+	if out == nil {
+		out = io.Discard
+	}
+	fmt.Fprint(out, cs)
+	if cs.Empty() {
+		return nil
+	}
+	if opts.Confirm != nil && !opts.Confirm(cs) {
+		fmt.Fprintln(out, "\nApply cancelled.")
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	harborIDs := maps.Clone(cs.harborIDs)
+	for _, change := range cs.Changes {
+		id, err := cs.apply(ctx, change, harborIDs)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", change.Action, change.Target(), err)
+		}
+		line := fmt.Sprintf("  %sd %s", change.Action, change.Target())
+		if id != "" {
+			line += fmt.Sprintf(" (%s)", id)
+		}
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintf(out, "\nApply complete: %d created, %d updated, %d deleted.\n",
+		cs.Count(ActionCreate), cs.Count(ActionUpdate), cs.Count(ActionDelete))
+	return nil
+}
+
+// apply executes one change and returns the ID of the changed resource.
+// harborIDs maps the names of the harbors created so far to their IDs.
+func (cs *ChangeSet) apply(ctx context.Context, change Change, harborIDs map[string]string) (string, error) {
+	switch {
+	case change.Kind == KindHarbor && change.Action == ActionCreate:
+		harbor, err := cs.org.CreateHarbor(ctx, change.Harbor)
+		if err != nil {
+			return "", err
+		}
+		harborIDs[change.Harbor] = harbor.ID
+		return harbor.ID, nil
+	case change.Kind == KindHarbor && change.Action == ActionDelete:
+		return change.ID, checkResponse(cs.org.Harbor(change.ID).Delete(ctx))
+	case change.Kind == KindDataDock && change.Action == ActionCreate:
+		harborID, ok := harborIDs[change.Harbor]
+		if !ok {
+			return "", fmt.Errorf("%w: harbor %s", utils.ErrNotFound, change.Harbor)
+		}
+		dataDock, err := cs.org.Harbor(harborID).CreateDataDock(ctx, change.create)
+		if err != nil {
+			return "", err
+		}
+		return dataDock.ID, nil
+	case change.Kind == KindDataDock && change.Action == ActionUpdate:
+		_, err := cs.org.Harbor("").DataDock(change.ID).Update(ctx, change.update)
+		return change.ID, err
+	case change.Kind == KindDataDock && change.Action == ActionDelete:
+		return change.ID, checkResponse(cs.org.Harbor("").DataDock(change.ID).Delete(ctx))
+	}
+	return "", fmt.Errorf("%w: unsupported change %s %s", utils.ErrInvalidRequest, change.Action, change.Kind)
+}
+
+func checkResponse(resp *utils.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp.Status != utils.StatusOK {
+		return fmt.Errorf("%w: %s", utils.ErrAPIError, resp.Error)
+	}
+	return nil
+}
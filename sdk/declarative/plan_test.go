@@ -0,0 +1,288 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// fakeOrg is an in-memory org serving the management endpoints used by
+// Plan and Apply.
+type fakeOrg struct {
+	harbors   []map[string]any
+	dataDocks []map[string]any
+	nextID    int
+	requests  []string
+	fail      string // "METHOD path" answered with an error
+}
+
+func (f *fakeOrg) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	path := strings.TrimPrefix(endpoint, "https://test.example.com")
+	path, _, _ = strings.Cut(path, "?")
+	f.requests = append(f.requests, method+" "+path)
+	if method+" "+path == f.fail {
+		return &utils.Response{Status: utils.StatusError, Error: "boom", HTTPCode: 500}, nil
+	}
+
+	var payload map[string]any
+	if body != nil {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+	}
+	ok := func(data any) (*utils.Response, error) {
+		return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+	}
+
+	switch {
+	case method == "GET" && path == "/acme/harbors":
+		return ok(map[string]any{"harbors": toList(f.harbors)})
+	case method == "GET" && path == "/acme/data-docks":
+		return ok(map[string]any{"data_docks": toList(f.dataDocks)})
+	case method == "POST" && path == "/acme/harbors":
+		f.nextID++
+		payload["id"] = fmt.Sprintf("h-new-%d", f.nextID)
+		f.harbors = append(f.harbors, payload)
+		return ok(payload)
+	case method == "POST" && path == "/data-docks":
+		f.nextID++
+		payload["id"] = fmt.Sprintf("dd-new-%d", f.nextID)
+		f.dataDocks = append(f.dataDocks, payload)
+		return ok(payload)
+	case method == "PATCH" && strings.HasPrefix(path, "/data-docks/"):
+		for _, dataDock := range f.dataDocks {
+			if "/data-docks/"+dataDock["id"].(string) == path {
+				for key, value := range payload {
+					dataDock[key] = value
+				}
+				return ok(dataDock)
+			}
+		}
+	case method == "DELETE":
+		f.harbors = remove(f.harbors, "/harbors/", path)
+		f.dataDocks = remove(f.dataDocks, "/data-docks/", path)
+		return ok(nil)
+	}
+	return &utils.Response{Status: utils.StatusError, HTTPCode: 404}, utils.ErrNotFound
+}
+
+func (f *fakeOrg) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func toList(items []map[string]any) []any {
+	list := make([]any, len(items))
+	for i, item := range items {
+		list[i] = item
+	}
+	return list
+}
+
+func remove(items []map[string]any, prefix, path string) []map[string]any {
+	var kept []map[string]any
+	for _, item := range items {
+		if prefix+item["id"].(string) != path {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func newFakeOrg() (*progressive.OrgBuilder, *fakeOrg) {
+	fake := &fakeOrg{
+		harbors: []map[string]any{
+			{"id": "h-1", "name": "analytics"},
+			{"id": "h-2", "name": "legacy"},
+		},
+		dataDocks: []map[string]any{
+			{"id": "dd-1", "name": "postgres-prod", "harbor_id": "h-1", "description": "old",
+				"connection_kind": map[string]any{"Trino": map[string]any{"host": "postgres.example.com", "port": 5432}}},
+			{"id": "dd-2", "name": "scratch", "harbor_id": "h-1", "connection_kind": "TrinoInternal"},
+			{"id": "dd-3", "name": "mysql", "harbor_id": "h-2", "connection_kind": "TrinoInternal"},
+		},
+	}
+	return &progressive.OrgBuilder{Client: fake, OrgID: "acme"}, fake
+}
+
+func mustParse(t *testing.T, data string) *Spec {
+	t.Helper()
+	spec, err := ParseSpec([]byte(data))
+	if err != nil {
+		t.Fatalf("Invalid test spec: %v", err)
+	}
+	return spec
+}
+
+const desiredSpec = `
+org: acme
+prune: true
+harbors:
+  - name: analytics
+    data_docks:
+      - name: postgres-prod
+        description: Production replica
+        connection: {type: Trino, config: {host: postgres.example.com, port: 5433}}
+  - name: marketing
+    data_docks:
+      - name: crm
+        connection: {type: TrinoInternal}
+`
+
+func TestPlan(t *testing.T) {
+	org, fake := newFakeOrg()
+
+	plan, err := Plan(context.Background(), org, mustParse(t, desiredSpec))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `Plan for org acme: 2 to create, 1 to update, 3 to delete.
+
+  + harbor marketing
+  + datadock marketing/crm (TrinoInternal)
+  ~ datadock analytics/postgres-prod (description, connection.port)
+  - datadock analytics/scratch (dd-2)
+  - datadock legacy/mysql (dd-3)
+  - harbor legacy (h-2)
+`
+	if plan.String() != expected {
+		t.Errorf("Unexpected plan:\n%s", plan)
+	}
+	for _, request := range fake.requests {
+		if !strings.HasPrefix(request, "GET ") {
+			t.Errorf("Expected Plan to only read, got %s", request)
+		}
+	}
+}
+
+func TestPlan_WithoutPrune(t *testing.T) {
+	org, _ := newFakeOrg()
+	spec := mustParse(t, desiredSpec)
+	spec.Prune = false
+
+	plan, err := Plan(context.Background(), org, spec)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if plan.Count(ActionDelete) != 0 || plan.Count(ActionCreate) != 2 || plan.Count(ActionUpdate) != 1 {
+		t.Errorf("Expected no deletes without prune, got:\n%s", plan)
+	}
+}
+
+func TestPlan_OrgMismatch(t *testing.T) {
+	org, _ := newFakeOrg()
+	org.OrgID = "globex"
+
+	if _, err := Plan(context.Background(), org, mustParse(t, desiredSpec)); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	org, fake := newFakeOrg()
+	spec := mustParse(t, desiredSpec)
+	plan, err := Plan(context.Background(), org, spec)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var out strings.Builder
+	if err := plan.Apply(context.Background(), ApplyOptions{Output: &out}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), plan.String()) {
+		t.Errorf("Expected the plan to be shown first, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "created datadock marketing/crm (dd-new-2)") ||
+		!strings.Contains(out.String(), "Apply complete: 2 created, 1 updated, 3 deleted.") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+	if fake.dataDocks[1]["harbor_id"] != "h-new-1" {
+		t.Errorf("Expected the datadock in the new harbor, got %v", fake.dataDocks[1])
+	}
+
+	// Applying converges: nothing is left to do
+	plan, err = Plan(context.Background(), org, spec)
+	if err != nil || !plan.Empty() {
+		t.Errorf("Expected no changes after apply, got:\n%s (%v)", plan, err)
+	}
+}
+
+func TestApply_Cancelled(t *testing.T) {
+	org, fake := newFakeOrg()
+	plan, _ := Plan(context.Background(), org, mustParse(t, desiredSpec))
+	reads := len(fake.requests)
+
+	err := plan.Apply(context.Background(), ApplyOptions{Confirm: func(*ChangeSet) bool { return false }})
+	if err != nil || len(fake.requests) != reads {
+		t.Errorf("Expected nothing to be applied, got %v (%v)", fake.requests[reads:], err)
+	}
+}
+
+func TestApply_StopsAtFirstFailure(t *testing.T) {
+	org, fake := newFakeOrg()
+	fake.fail = "PATCH /data-docks/dd-1"
+	plan, _ := Plan(context.Background(), org, mustParse(t, desiredSpec))
+
+	err := plan.Apply(context.Background(), ApplyOptions{})
+	if !errors.Is(err, utils.ErrAPIError) || !strings.Contains(err.Error(), "update datadock analytics/postgres-prod") {
+		t.Fatalf("Expected the failed update, got %v", err)
+	}
+	if len(fake.harbors) != 3 || len(fake.dataDocks) != 4 {
+		t.Errorf("Expected creates to be applied and deletes skipped, got %d harbors, %d datadocks", len(fake.harbors), len(fake.dataDocks))
+	}
+}
+
+func TestPlan_SecretBearingConnectionConfig(t *testing.T) {
+	t.Setenv("PG_PASSWORD", "s3cret")
+	spec := mustParse(t, `
+harbors:
+  - name: analytics
+    data_docks:
+      - name: postgres-prod
+        description: old
+        connection:
+          type: Trino
+          config: {host: postgres.example.com, port: 5432, password: "${PG_PASSWORD}", api_key: "${PG_PASSWORD}", tls: {enabled: true, ca: "${PG_PASSWORD}"}}
+`)
+
+	plan := func(config map[string]any) *ChangeSet {
+		t.Helper()
+		org, fake := newFakeOrg()
+		fake.dataDocks[0]["connection_kind"] = map[string]any{"Trino": config}
+		changes, err := Plan(context.Background(), org, spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return changes
+	}
+
+	// Redacted and omitted secrets, server defaults and numbers echoed as
+	// strings are not changes
+	unchanged := plan(map[string]any{
+		"host":     "postgres.example.com",
+		"port":     "5432",
+		"password": "********",
+		"sslmode":  "prefer",
+		"tls":      map[string]any{"enabled": true, "ca": "[REDACTED]"},
+	})
+	if !unchanged.Empty() {
+		t.Errorf("Expected no changes, got:\n%s", unchanged)
+	}
+
+	changed := plan(map[string]any{
+		"host":     "replica.example.com",
+		"port":     5432,
+		"password": "********",
+		"tls":      map[string]any{"enabled": false},
+	})
+	if !strings.Contains(changed.String(), "~ datadock analytics/postgres-prod (connection.host, connection.tls)") {
+		t.Errorf("Expected host and tls updates, got:\n%s", changed)
+	}
+}
@@ -0,0 +1,185 @@
+// Package declarative syncs an organization's harbors and datadocks with a
+// spec file: Plan compares the spec with the live state read through the
+// progressive builders, and Apply executes the resulting changes.
+//
+//	spec, err := declarative.LoadSpec("bifrost.yaml")
+//	plan, err := declarative.Plan(ctx, client.Org(spec.Org), spec)
+//	fmt.Print(plan)
+//	err = plan.Apply(ctx, declarative.ApplyOptions{Output: os.Stdout})
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// Spec is the desired state of an organization. Harbors and datadocks are
+// matched with the live ones by name, so renaming one in the spec plans a
+// delete (with Prune) and a create.
+//
+//	org: acme
+//	prune: true
+//	harbors:
+//	  - name: analytics
+//	    data_docks:
+//	      - name: postgres-prod
+//	        description: Production replica
+//	        connection:
+//	          type: Trino
+//	          config:
+//	            host: postgres.example.com
+//	            port: 5432
+//	            password: ${PG_PASSWORD}
+type Spec struct {
+	Org     string       `yaml:"org,omitempty"`   // Optional, checked against the org being planned
+	Prune   bool         `yaml:"prune,omitempty"` // Delete harbors and datadocks missing from the spec
+	Harbors []HarborSpec `yaml:"harbors"`
+}
+
+// HarborSpec is a desired harbor and its datadocks.
+type HarborSpec struct {
+	Name      string         `yaml:"name"`
+	DataDocks []DataDockSpec `yaml:"data_docks,omitempty"`
+}
+
+// DataDockSpec is a desired datadock.
+type DataDockSpec struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description,omitempty"`
+	Connection  ConnectionSpec `yaml:"connection"`
+}
+
+// ConnectionSpec is the YAML form of progressive.ConnectionKind.
+type ConnectionSpec struct {
+	Type   string         `yaml:"type"`
+	Config map[string]any `yaml:"config,omitempty"`
+}
+
+// Kind returns the connection as sent to the API. Config values are
+// normalized to their JSON types, so they compare equal to live ones.
+func (c ConnectionSpec) Kind() (progressive.ConnectionKind, error) {
+	kind := progressive.ConnectionKind{Type: c.Type}
+	if c.Config == nil {
+		return kind, nil
+	}
+	data, err := json.Marshal(c.Config)
+	if err != nil {
+		return kind, fmt.Errorf("%w: connection config of type %s: %w", utils.ErrInvalidRequest, c.Type, err)
+	}
+	if err := json.Unmarshal(data, &kind.Config); err != nil {
+		return kind, fmt.Errorf("%w: connection config of type %s: %w", utils.ErrInvalidRequest, c.Type, err)
+	}
+	return kind, nil
+}
+
+// LoadSpec reads and validates a spec file. See ParseSpec.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseSpec decodes and validates a YAML spec. ${VAR} references in
+// connection config strings are replaced with environment variables, so
+// secrets stay out of the file; an unset variable is an error.
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+	}
+	for i := range spec.Harbors {
+		for j := range spec.Harbors[i].DataDocks {
+			dataDock := spec.Harbors[i].DataDocks[j]
+			if _, err := expandEnv(dataDock.Connection.Config); err != nil {
+				return nil, fmt.Errorf("%w: datadock %s: %w", utils.ErrInvalidRequest, dataDock.Name, err)
+			}
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that every harbor and datadock has a unique name and that
+// every datadock has a connection type.
+func (s *Spec) Validate() error {
+	harbors := map[string]bool{}
+	for _, harbor := range s.Harbors {
+		if harbor.Name == "" {
+			return fmt.Errorf("%w: harbor name is required", utils.ErrInvalidRequest)
+		}
+		if harbors[harbor.Name] {
+			return fmt.Errorf("%w: harbor %s is declared twice", utils.ErrInvalidRequest, harbor.Name)
+		}
+		harbors[harbor.Name] = true
+
+		dataDocks := map[string]bool{}
+		for _, dataDock := range harbor.DataDocks {
+			if dataDock.Name == "" {
+				return fmt.Errorf("%w: harbor %s: datadock name is required", utils.ErrInvalidRequest, harbor.Name)
+			}
+			if dataDocks[dataDock.Name] {
+				return fmt.Errorf("%w: harbor %s: datadock %s is declared twice", utils.ErrInvalidRequest, harbor.Name, dataDock.Name)
+			}
+			dataDocks[dataDock.Name] = true
+			if dataDock.Connection.Type == "" {
+				return fmt.Errorf("%w: datadock %s/%s: connection type is required", utils.ErrInvalidRequest, harbor.Name, dataDock.Name)
+			}
+		}
+	}
+	return nil
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${VAR} references in the strings of a decoded YAML
+// value. Maps and slices are updated in place.
+func expandEnv(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		var missing string
+		expanded := envReference.ReplaceAllStringFunc(v, func(ref string) string {
+			name := envReference.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok && missing == "" {
+				missing = name
+			}
+			return value
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("environment variable %s is not set", missing)
+		}
+		return expanded, nil
+	case map[string]any:
+		for key, item := range v {
+			expanded, err := expandEnv(item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = expanded
+		}
+	case []any:
+		for i, item := range v {
+			expanded, err := expandEnv(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,83 @@
+package declarative
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+const testSpec = `
+org: acme
+prune: true
+harbors:
+  - name: analytics
+    data_docks:
+      - name: postgres-prod
+        description: Production replica
+        connection:
+          type: Trino
+          config:
+            host: postgres.example.com
+            port: 5432
+            password: ${BIFROST_TEST_PASSWORD}
+            options: ["ssl=${BIFROST_TEST_SSL}"]
+      - name: internal
+        connection:
+          type: TrinoInternal
+`
+
+func TestParseSpec(t *testing.T) {
+	t.Setenv("BIFROST_TEST_PASSWORD", "s3cret")
+	t.Setenv("BIFROST_TEST_SSL", "on")
+
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec.Org != "acme" || !spec.Prune || len(spec.Harbors) != 1 || len(spec.Harbors[0].DataDocks) != 2 {
+		t.Fatalf("Unexpected spec %+v", spec)
+	}
+
+	config := spec.Harbors[0].DataDocks[0].Connection.Config
+	if config["password"] != "s3cret" || config["options"].([]any)[0] != "ssl=on" {
+		t.Errorf("Expected environment references to be expanded, got %v", config)
+	}
+
+	kind, err := spec.Harbors[0].DataDocks[0].Connection.Kind()
+	if err != nil || kind.Type != "Trino" || kind.Config["port"] != float64(5432) {
+		t.Errorf("Expected JSON-normalized config, got %+v (%v)", kind, err)
+	}
+	if kind, _ := spec.Harbors[0].DataDocks[1].Connection.Kind(); kind.Type != "TrinoInternal" || kind.Config != nil {
+		t.Errorf("Expected a connection without settings, got %+v", kind)
+	}
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing env":        "harbors: [{name: h, data_docks: [{name: d, connection: {type: T, config: {password: '${BIFROST_TEST_UNSET}'}}}]}]",
+		"duplicate harbor":   "harbors: [{name: h}, {name: h}]",
+		"duplicate datadock": "harbors: [{name: h, data_docks: [{name: d, connection: {type: T}}, {name: d, connection: {type: T}}]}]",
+		"missing type":       "harbors: [{name: h, data_docks: [{name: d}]}]",
+		"missing name":       "harbors: [{data_docks: []}]",
+		"not yaml":           "harbors: [",
+	}
+	for name, data := range tests {
+		if _, err := ParseSpec([]byte(data)); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bifrost.yaml")
+	if err := os.WriteFile(path, []byte("harbors: [{name: h}, {name: h}]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadSpec(path)
+	if !errors.Is(err, utils.ErrInvalidRequest) || err.Error()[:len(path)] != path {
+		t.Errorf("Expected an error naming the file, got %v", err)
+	}
+}
@@ -8,18 +8,29 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
 )
 
-func (c *Client) do(ctx context.Context, method, url string, body []byte) (*utils.Response, error) {
+func (c *Client) do(ctx context.Context, method, url string, body []byte, headers http.Header) (*utils.Response, error) {
+	return c.doWithAutoWake(ctx, method, url, body, headers)
+}
+
+func (c *Client) doRequest(ctx context.Context, method, url string, body []byte, headers http.Header) (*utils.Response, error) {
 	var lastErr error
 	var lastResp *utils.Response
+	var retryAfter time.Duration
 
 	for i := 0; i <= c.config.MaxRetries; i++ {
 		if i > 0 {
 			delay := time.Duration(math.Pow(2, float64(i-1))*100) * time.Millisecond
+			// Honor the server's Retry-After when rate limited
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+			retryAfter = 0
 			// Respect context cancellation during backoff
 			select {
 			case <-time.After(delay):
@@ -46,6 +57,11 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 			}
 		}
 
+		for key, values := range headers {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
 		req.Header.Set("Authorization", "Bearer "+c.config.Token)
 		if body != nil {
 			req.Header.Set("Content-Type", "application/json")
@@ -65,11 +81,13 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 			continue
 		}
 
-		if resp.StatusCode >= 300 {
+		// 304 Not Modified answers a conditional GET: success without a body
+		if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
 			lastResp = &utils.Response{
 				Status:   utils.StatusError,
 				Error:    string(respBody),
 				HTTPCode: resp.StatusCode,
+				Header:   resp.Header,
 			}
 
 			if resp.StatusCode == http.StatusUnauthorized {
@@ -89,6 +107,24 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 				return lastResp, utils.ErrNotFound
 			}
 
+			if resp.StatusCode == http.StatusPreconditionFailed {
+				return lastResp, utils.ErrPreconditionFailed
+			}
+
+			// Only worth short-circuiting the retries when it can be woken up
+			if c.config.AutoWakeDataDocks && isDataDockSleeping(resp.StatusCode, respBody) {
+				return lastResp, fmt.Errorf("%w: %s", utils.ErrDataDockSleeping, string(respBody))
+			}
+
+			// Rate limited: retry after the delay requested by the server
+			if resp.StatusCode == http.StatusTooManyRequests {
+				if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+					retryAfter = time.Duration(seconds) * time.Second
+				}
+				lastErr = fmt.Errorf("rate limited (status %d)", resp.StatusCode)
+				continue
+			}
+
 			// Do not retry on other 4xx client errors
 			if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 				return lastResp, fmt.Errorf("%w: %s", utils.ErrInvalidRequest, string(respBody))
@@ -98,16 +134,23 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 			continue
 		}
 
+		// Empty bodies (e.g. 204 No Content) are valid and leave Data nil;
+		// Arrow IPC streams are passed through undecoded
 		var parsedBody any
-		if err := json.Unmarshal(respBody, &parsedBody); err != nil {
-			lastErr = fmt.Errorf("failed to parse response body: %w", err)
-			continue
+		if utils.IsArrowStream(resp.Header) {
+			parsedBody = respBody
+		} else if len(bytes.TrimSpace(respBody)) > 0 {
+			if err := json.Unmarshal(respBody, &parsedBody); err != nil {
+				lastErr = fmt.Errorf("failed to parse response body: %w", err)
+				continue
+			}
 		}
 
 		return &utils.Response{
 			Status:   utils.StatusOK,
 			Data:     parsedBody,
 			HTTPCode: resp.StatusCode,
+			Header:   resp.Header,
 		}, nil
 	}
 
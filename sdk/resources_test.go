@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/utils"
+)
+
+// fakeAPI is an in-memory implementation of the management endpoints.
+type fakeAPI struct {
+	mu      sync.Mutex
+	orgs    map[string]map[string]any
+	members map[string][]map[string]any
+	harbors map[string]map[string]any
+}
+
+func newFakeAPI(t *testing.T) (*Client, *fakeAPI) {
+	t.Helper()
+	api := &fakeAPI{
+		orgs: map[string]map[string]any{
+			"acme": {"id": "acme", "name": "acme", "display_name": "Acme Corp",
+				"quotas": map[string]any{"max_harbors": 5, "max_data_docks": 50}},
+			"globex": {"id": "globex", "name": "globex"},
+		},
+		members: map[string][]map[string]any{
+			"acme": {
+				{"user_id": "u-1", "email": "ada@acme.test", "role": "owner", "joined_at": "2025-06-01T00:00:00Z"},
+				{"user_id": "u-2", "email": "lin@acme.test", "role": "member"},
+			},
+		},
+		harbors: map[string]map[string]any{
+			"h-1": {"id": "h-1", "name": "main", "org_id": "acme"},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(api.serve))
+	t.Cleanup(server.Close)
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "test-token", OrgID: "acme"})
+	return client, api
+}
+
+func (api *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+	reply := func(value any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(value)
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/orgs":
+		var orgs []any
+		for _, id := range []string{"acme", "globex"} {
+			orgs = append(orgs, api.orgs[id])
+		}
+		reply(map[string]any{"orgs": orgs})
+	case r.Method == "GET" && len(parts) == 1:
+		if org, ok := api.orgs[parts[0]]; ok {
+			reply(org)
+			return
+		}
+		http.Error(w, `{"error": "org not found"}`, http.StatusNotFound)
+	case r.Method == "GET" && len(parts) == 2 && parts[1] == "members":
+		reply(map[string]any{"members": api.members[parts[0]]})
+	case len(parts) == 2 && parts[0] == "harbors":
+		harbor, ok := api.harbors[parts[1]]
+		if !ok {
+			http.Error(w, `{"error": "harbor not found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == "PATCH" {
+			var patch map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				http.Error(w, `{"error": "bad body"}`, http.StatusBadRequest)
+				return
+			}
+			for key, value := range patch {
+				harbor[key] = value
+			}
+		}
+		reply(harbor)
+	default:
+		http.Error(w, `{"error": "no route"}`, http.StatusNotFound)
+	}
+}
+
+func TestListOrgs(t *testing.T) {
+	client, _ := newFakeAPI(t)
+
+	orgs, err := client.ListOrgs(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(orgs) != 2 || orgs[0].ID != "acme" || orgs[1].ID != "globex" {
+		t.Errorf("Unexpected orgs %+v", orgs)
+	}
+}
+
+func TestOrgBuilder_GetAndListMembers(t *testing.T) {
+	client, _ := newFakeAPI(t)
+	ctx := context.Background()
+
+	org, err := client.OrgFromConfig().Get(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if org.DisplayName != "Acme Corp" || org.Quotas.MaxHarbors != 5 || org.Quotas.MaxDataDocks != 50 {
+		t.Errorf("Unexpected org %+v", org)
+	}
+
+	members, err := client.Org("acme").ListMembers(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(members) != 2 || members[0].Role != "owner" || members[0].JoinedAt.Year() != 2025 {
+		t.Errorf("Unexpected members %+v", members)
+	}
+
+	if _, err := client.Org("missing").Get(ctx); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestHarborBuilder_GetAndUpdate(t *testing.T) {
+	client, api := newFakeAPI(t)
+	ctx := context.Background()
+	harbor := client.Org("acme").Harbor("h-1")
+
+	got, err := harbor.Get(ctx)
+	if err != nil || got.Name != "main" || got.OrgID != "acme" {
+		t.Fatalf("Unexpected harbor %+v (%v)", got, err)
+	}
+
+	name := "analytics"
+	updated, err := harbor.Update(ctx, progressive.UpdateHarborRequest{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != "analytics" || api.harbors["h-1"]["name"] != "analytics" {
+		t.Errorf("Expected the harbor to be renamed, got %+v", updated)
+	}
+
+	if _, err := harbor.Update(ctx, progressive.UpdateHarborRequest{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an empty update, got %v", err)
+	}
+	if _, err := client.Org("acme").Harbor("missing").Get(ctx); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -9,4 +9,11 @@ var (
 	ErrPermissionDenied     = errors.New("permission denied")
 	ErrInvalidRequest       = errors.New("invalid request")
 	ErrAPIError             = errors.New("API error")
+	ErrInvalidResponse      = errors.New("invalid response")
+	ErrDryRun               = errors.New("dry run: request not sent")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrUnfilteredWrite      = errors.New("write without filters affects every row")
+	ErrSchemaDrift          = errors.New("breaking schema change")
+	ErrDataDockFailed       = errors.New("data dock failed")
+	ErrDataDockSleeping     = errors.New("data dock is sleeping")
 )
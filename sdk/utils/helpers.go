@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 	"os"
 	"strconv"
@@ -17,6 +18,12 @@ const (
 
 	// DefaultMaxRetries is the default number of retry attempts for failed requests.
 	DefaultMaxRetries = 3
+
+	// DefaultCatalogCacheTTL is the suggested lifetime of cached catalog metadata.
+	DefaultCatalogCacheTTL = time.Minute
+
+	// DefaultAutoWakeTimeout is how long an automatic wake-up waits for a data dock.
+	DefaultAutoWakeTimeout = 5 * time.Minute
 )
 
 // SecondsToDuration converts an integer number of seconds to time.Duration.
@@ -43,6 +50,37 @@ func GetEnvironmentVariableInt(key string, fallback int) int {
 	return fallback
 }
 
+// ConfigurationFromEnv builds a Configuration from the HYPERFLUID_*,
+// KEYCLOAK_* and MINIO_* environment variables documented in the README.
+// Unset variables leave the field at its zero value, except the request
+// timeout and retries which use their defaults.
+func ConfigurationFromEnv() Configuration {
+	return Configuration{
+		BaseURL:        GetEnvironmentVariable("HYPERFLUID_BASE_URL", ""),
+		OrgID:          GetEnvironmentVariable("HYPERFLUID_ORG_ID", ""),
+		DataDockID:     GetEnvironmentVariable("HYPERFLUID_DATADOCK_ID", ""),
+		Token:          GetEnvironmentVariable("HYPERFLUID_TOKEN", ""),
+		RequestTimeout: DefaultRequestTimeout,
+		MaxRetries:     GetEnvironmentVariableInt("HYPERFLUID_MAX_RETRIES", DefaultMaxRetries),
+
+		AutoWakeDataDocks: GetEnvironmentVariable("HYPERFLUID_AUTO_WAKE", "") == "true",
+
+		KeycloakBaseURL:      GetEnvironmentVariable("KEYCLOAK_BASE_URL", ""),
+		KeycloakRealm:        GetEnvironmentVariable("KEYCLOAK_REALM", ""),
+		KeycloakClientID:     GetEnvironmentVariable("KEYCLOAK_CLIENT_ID", ""),
+		KeycloakClientSecret: GetEnvironmentVariable("KEYCLOAK_CLIENT_SECRET", ""),
+		KeycloakUsername:     GetEnvironmentVariable("KEYCLOAK_USERNAME", ""),
+		KeycloakPassword:     GetEnvironmentVariable("KEYCLOAK_PASSWORD", ""),
+
+		MinIORegion:    GetEnvironmentVariable("MINIO_REGION", ""),
+		MinIOEndpoint:  GetEnvironmentVariable("MINIO_ENDPOINT", ""),
+		MinIOAccessKey: GetEnvironmentVariable("MINIO_ACCESS_KEY", ""),
+		MinIOSecretKey: GetEnvironmentVariable("MINIO_SECRET_KEY", ""),
+		MinIOUseSSL:    GetEnvironmentVariable("MINIO_USE_SSL", ""),
+		MinIOUseOIDC:   GetEnvironmentVariable("MINIO_USE_OIDC", ""),
+	}
+}
+
 // HTTP client handling
 func CreateHTTPClientWithSettings(skipTLSVerification bool, timeoutDuration time.Duration) *http.Client {
 	transport := &http.Transport{}
@@ -71,6 +109,13 @@ func JsonMarshal(value any) []byte {
 	encodedBytes, _ := json.Marshal(value)
 	return encodedBytes
 }
+
+// IsArrowStream reports whether header declares an Arrow IPC stream body
+// (see ArrowStreamMediaType).
+func IsArrowStream(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == ArrowStreamMediaType
+}
 func (response *Response) GetDataAsSlice() ([]any, bool) {
 	sliceValue, isSlice := response.Data.([]any)
 	return sliceValue, isSlice
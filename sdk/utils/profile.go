@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named set of connection settings in a profiles file. Empty
+// fields fall back to the environment (see ConfigurationFromEnv).
+type Profile struct {
+	BaseURL       string `yaml:"base_url,omitempty"`
+	OrgID         string `yaml:"org_id,omitempty"`
+	DataDockID    string `yaml:"datadock_id,omitempty"`
+	Token         string `yaml:"token,omitempty"`
+	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty"`
+	MaxRetries    int    `yaml:"max_retries,omitempty"`
+	AutoWake      bool   `yaml:"auto_wake,omitempty"`
+
+	KeycloakBaseURL      string `yaml:"keycloak_base_url,omitempty"`
+	KeycloakRealm        string `yaml:"keycloak_realm,omitempty"`
+	KeycloakClientID     string `yaml:"keycloak_client_id,omitempty"`
+	KeycloakClientSecret string `yaml:"keycloak_client_secret,omitempty"`
+	KeycloakUsername     string `yaml:"keycloak_username,omitempty"`
+	KeycloakPassword     string `yaml:"keycloak_password,omitempty"`
+
+	MinIORegion    string `yaml:"minio_region,omitempty"`
+	MinIOEndpoint  string `yaml:"minio_endpoint,omitempty"`
+	MinIOAccessKey string `yaml:"minio_access_key,omitempty"`
+	MinIOSecretKey string `yaml:"minio_secret_key,omitempty"`
+	MinIOUseSSL    string `yaml:"minio_use_ssl,omitempty"`
+	MinIOUseOIDC   string `yaml:"minio_use_oidc,omitempty"`
+}
+
+// Profiles is the content of a profiles file:
+//
+//	default: prod
+//	profiles:
+//	  prod:
+//	    base_url: https://bifrost.hyperfluid.cloud
+//	    org_id: acme
+//	    token: ...
+//	  staging:
+//	    base_url: https://staging.example.com
+//	    keycloak_base_url: https://auth.example.com
+//	    keycloak_realm: hyperfluid
+//	    keycloak_client_id: bifrost-cli
+type Profiles struct {
+	Default  string             `yaml:"default,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// ProfilesPath returns the profiles file location: BIFROST_CONFIG, or
+// ~/.bifrost/config.yaml.
+func ProfilesPath() string {
+	if path := os.Getenv("BIFROST_CONFIG"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".bifrost", "config.yaml")
+}
+
+// LoadProfiles reads a profiles file.
+func LoadProfiles(path string) (*Profiles, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var profiles Profiles
+	if err := yaml.Unmarshal(content, &profiles); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfiguration, path, err)
+	}
+	return &profiles, nil
+}
+
+// Names returns the profile names in alphabetical order.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigurationFromProfile builds a Configuration from a profile of the
+// profiles file (see ProfilesPath), on top of ConfigurationFromEnv: the
+// profile's settings win, and the ones it leaves empty come from the
+// environment.
+//
+// An empty name selects HYPERFLUID_PROFILE, then the file's default
+// profile. Without either, or without a profiles file, the configuration
+// comes from the environment alone. A profile asked for by name must exist.
+func ConfigurationFromProfile(name string) (Configuration, error) {
+	config := ConfigurationFromEnv()
+	if name == "" {
+		name = os.Getenv("HYPERFLUID_PROFILE")
+	}
+
+	path := ProfilesPath()
+	profiles, err := LoadProfiles(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && name == "":
+		return config, nil
+	case errors.Is(err, os.ErrNotExist):
+		return config, fmt.Errorf("%w: profile %q: no profiles file at %s", ErrInvalidConfiguration, name, path)
+	case err != nil:
+		return config, err
+	}
+
+	if name == "" {
+		name = profiles.Default
+		if name == "" {
+			return config, nil
+		}
+	}
+	profile, ok := profiles.Profiles[name]
+	if !ok {
+		return config, fmt.Errorf("%w: profile %q not found in %s", ErrInvalidConfiguration, name, path)
+	}
+	profile.applyTo(&config)
+	return config, nil
+}
+
+// applyTo overrides the fields of config that the profile sets.
+func (p Profile) applyTo(config *Configuration) {
+	set := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	set(&config.BaseURL, p.BaseURL)
+	set(&config.OrgID, p.OrgID)
+	set(&config.DataDockID, p.DataDockID)
+	set(&config.Token, p.Token)
+	config.SkipTLSVerify = config.SkipTLSVerify || p.SkipTLSVerify
+	if p.MaxRetries > 0 {
+		config.MaxRetries = p.MaxRetries
+	}
+	config.AutoWakeDataDocks = config.AutoWakeDataDocks || p.AutoWake
+
+	set(&config.KeycloakBaseURL, p.KeycloakBaseURL)
+	set(&config.KeycloakRealm, p.KeycloakRealm)
+	set(&config.KeycloakClientID, p.KeycloakClientID)
+	set(&config.KeycloakClientSecret, p.KeycloakClientSecret)
+	set(&config.KeycloakUsername, p.KeycloakUsername)
+	set(&config.KeycloakPassword, p.KeycloakPassword)
+
+	set(&config.MinIORegion, p.MinIORegion)
+	set(&config.MinIOEndpoint, p.MinIOEndpoint)
+	set(&config.MinIOAccessKey, p.MinIOAccessKey)
+	set(&config.MinIOSecretKey, p.MinIOSecretKey)
+	set(&config.MinIOUseSSL, p.MinIOUseSSL)
+	set(&config.MinIOUseOIDC, p.MinIOUseOIDC)
+}
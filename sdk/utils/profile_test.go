@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testProfiles = `
+default: prod
+profiles:
+  prod:
+    base_url: https://prod.example.com
+    org_id: acme
+    token: prod-token
+  staging:
+    base_url: https://staging.example.com
+    max_retries: 7
+    auto_wake: true
+`
+
+func writeProfiles(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BIFROST_CONFIG", path)
+	t.Setenv("HYPERFLUID_PROFILE", "")
+	t.Setenv("HYPERFLUID_ORG_ID", "env-org")
+	t.Setenv("HYPERFLUID_TOKEN", "env-token")
+}
+
+func TestConfigurationFromProfile(t *testing.T) {
+	writeProfiles(t, testProfiles)
+
+	config, err := ConfigurationFromProfile("")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.BaseURL != "https://prod.example.com" || config.OrgID != "acme" || config.Token != "prod-token" {
+		t.Errorf("Expected the default profile, got %+v", config)
+	}
+
+	config, err = ConfigurationFromProfile("staging")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.BaseURL != "https://staging.example.com" || config.MaxRetries != 7 || !config.AutoWakeDataDocks {
+		t.Errorf("Expected the staging profile, got %+v", config)
+	}
+	if config.OrgID != "env-org" || config.Token != "env-token" {
+		t.Errorf("Expected unset fields to come from the environment, got %+v", config)
+	}
+
+	t.Setenv("HYPERFLUID_PROFILE", "staging")
+	if config, _ := ConfigurationFromProfile(""); config.BaseURL != "https://staging.example.com" {
+		t.Errorf("Expected HYPERFLUID_PROFILE to select staging, got %s", config.BaseURL)
+	}
+
+	if _, err := ConfigurationFromProfile("missing"); !errors.Is(err, ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestConfigurationFromProfile_NoFile(t *testing.T) {
+	writeProfiles(t, "")
+	t.Setenv("BIFROST_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	config, err := ConfigurationFromProfile("")
+	if err != nil || config.OrgID != "env-org" {
+		t.Errorf("Expected the environment configuration, got %+v (%v)", config, err)
+	}
+	if _, err := ConfigurationFromProfile("prod"); !errors.Is(err, ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a named profile, got %v", err)
+	}
+}
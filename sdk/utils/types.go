@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -14,6 +15,17 @@ type Configuration struct {
 	RequestTimeout time.Duration
 	MaxRetries     int
 
+	// CatalogCacheTTL enables the catalog metadata cache: catalogs are reused
+	// for this long before being revalidated. The cache is disabled when it
+	// is zero or negative; DefaultCatalogCacheTTL is a reasonable value.
+	CatalogCacheTTL time.Duration
+
+	// AutoWakeDataDocks makes requests to a sleeping data dock wake it up,
+	// wait until it is running (at most AutoWakeTimeout, default
+	// DefaultAutoWakeTimeout) and retry once.
+	AutoWakeDataDocks bool
+	AutoWakeTimeout   time.Duration
+
 	KeycloakBaseURL      string
 	KeycloakRealm        string
 	KeycloakClientID     string
@@ -34,9 +46,15 @@ type Response struct {
 	Data     any
 	Error    string
 	HTTPCode int
+	Header   http.Header
 }
 
 const (
 	StatusOK    = "ok"
 	StatusError = "error"
 )
+
+// ArrowStreamMediaType is the media type of Arrow IPC streams. Successful
+// responses of this type are not decoded as JSON: Response.Data holds the
+// raw stream as a []byte.
+const ArrowStreamMediaType = "application/vnd.apache.arrow.stream"
@@ -4,8 +4,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk"
+	"github.com/nudibranches-tech/bifrost-hyperfluid-sdk-dev/sdk/builders/progressive"
 )
 
 // This file demonstrates the NEW progressive fluent API
@@ -31,11 +33,18 @@ func runProgressiveAPIExample1() {
 	fmt.Println()
 
 	// NEW! Each level has its own type with specific methods
-	resp, err := client.
+	harbors, err := client.
 		Org(orgID).                       // Returns OrgBuilder with org-specific methods
 		ListHarbors(context.Background()) // Only available on OrgBuilder!
 
-	handleResponse(resp, err)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("✅ Found %d harbors\n", len(harbors))
+		for _, harbor := range harbors {
+			fmt.Printf("   - %s (%s)\n", harbor.Name, harbor.ID)
+		}
+	}
 	fmt.Println()
 }
 
@@ -60,12 +69,19 @@ func runProgressiveAPIExample2() {
 	fmt.Println("   client.Org(orgID).Harbor(harborID).ListDataDocks(ctx)")
 	fmt.Println()
 
-	resp, err := client.
+	datadocks, err := client.
 		Org(orgID).
 		Harbor(harborID).                   // Returns HarborBuilder with harbor-specific methods
 		ListDataDocks(context.Background()) // Only available on HarborBuilder!
 
-	handleResponse(resp, err)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("✅ Found %d datadocks\n", len(datadocks))
+		for _, datadock := range datadocks {
+			fmt.Printf("   - %s (%s, %s, %s)\n", datadock.Name, datadock.ID, datadock.Connection.Type, datadock.Status)
+		}
+	}
 	fmt.Println()
 }
 
@@ -133,8 +149,15 @@ func runProgressiveAPIExample4() {
 
 	// Example: Refresh catalog
 	fmt.Println("🔄 Refreshing catalog...")
-	resp, err := datadock.RefreshCatalog(context.Background())
-	handleResponse(resp, err)
+	op, err := datadock.RefreshCatalog(context.Background())
+	if err == nil {
+		_, err = op.Wait(context.Background(), progressive.WaitOptions{Timeout: 5 * time.Minute})
+	}
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+	} else {
+		fmt.Println("✅ Catalog refreshed")
+	}
 	fmt.Println()
 }
 
@@ -256,8 +279,7 @@ func runProgressiveAPIListingExample() {
 	// List harbors in org
 	fmt.Println("1. List Harbors in Organization:")
 	if harbors, err := client.Org(orgID).ListHarbors(context.Background()); err == nil {
-		fmt.Printf("   ✓ Found harbors\n")
-		_ = harbors
+		fmt.Printf("   ✓ Found %d harbors\n", len(harbors))
 	} else {
 		fmt.Printf("   ✗ Error: %v\n", err)
 	}
@@ -271,8 +293,7 @@ func runProgressiveAPIListingExample() {
 	// List datadocks in harbor
 	fmt.Println("2. List DataDocks in Harbor:")
 	if datadocks, err := client.Org(orgID).Harbor(harborID).ListDataDocks(context.Background()); err == nil {
-		fmt.Printf("   ✓ Found datadocks\n")
-		_ = datadocks
+		fmt.Printf("   ✓ Found %d datadocks\n", len(datadocks))
 	} else {
 		fmt.Printf("   ✗ Error: %v\n", err)
 	}